@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
 	"course-reg/internal/app/domain/export"
+	"course-reg/internal/app/domain/idempotency"
+	"course-reg/internal/app/domain/journal"
 	"course-reg/internal/app/domain/registration"
 	"course-reg/internal/app/domain/worker"
 	"course-reg/internal/app/handler"
@@ -18,17 +21,24 @@ import (
 	"course-reg/internal/app/service"
 	"course-reg/internal/pkg/database"
 	"course-reg/internal/pkg/setting"
+	"course-reg/internal/pkg/utils"
 )
 
 // Application contains all application components and their dependencies
 type Application struct {
-	DB       *gorm.DB
-	Worker   *worker.EnrollmentWorker
-	RegState *registration.State
-	Router   *gin.Engine
+	DB           *gorm.DB
+	Worker       *worker.EnrollmentWorker
+	AdminService *service.AdminService
+	Journal      *journal.Journal
+	RegState     *registration.State
+	RegScheduler *registration.Scheduler
+	Router       *gin.Engine
 }
 
-const workerQueueSize = 1000
+const (
+	registrationCheckInterval = time.Second
+	defaultTermName           = "default"
+)
 
 // NewApplication creates and initializes the entire application.
 // Dependencies are explicitly passed to each component, so incorrect ordering
@@ -46,35 +56,67 @@ func NewApplication(cfg *setting.Config) (*Application, error) {
 	courseRepo := repository.NewCourseRepository(db)
 	enrollRepo := repository.NewEnrollmentRepository(db)
 	regConfigRepo := repository.NewRegistrationConfigRepository(db)
+	roundRepo := repository.NewRegistrationRoundRepository(db)
+	cohortRepo := repository.NewCohortRepository(db)
+	lotteryRepo := repository.NewLotteryRepository(db)
+	preferenceRepo := repository.NewPreferenceRepository(db)
+	cartRepo := repository.NewCartRepository(db)
+	adminLogRepo := repository.NewAdminEnrollmentLogRepository(db)
+	studentLimitRepo := repository.NewStudentLimitRepository(db)
+	specialRuleRepo := repository.NewSpecialCourseRuleRepository(db)
+	courseGroupRepo := repository.NewCourseGroupRepository(db)
+	eligibilityRepo := repository.NewEligibilityRepository(db)
+	completionRepo := repository.NewCompletionRepository(db)
+	prerequisiteRepo := repository.NewPrerequisiteRepository(db)
+	termRepo := repository.NewTermRepository(db)
 	log.Println("[info] repositories setup completed")
 
-	// 3. Static files (depends on: courseRepo)
-	if err := export.ExportCoursesToJson(courseRepo); err != nil {
+	// 3. Active term (depends on: termRepo)
+	term, err := loadActiveTerm(termRepo)
+	if err != nil {
+		return nil, fmt.Errorf("term setup failed: %w", err)
+	}
+	log.Printf("[info] term setup completed (term: %s)", term.Name)
+
+	// 4. Static files (depends on: courseRepo, term)
+	if err := export.ExportCoursesToJson(courseRepo, term.ID); err != nil {
 		return nil, fmt.Errorf("static files setup failed: %w", err)
 	}
 	log.Println("[info] static files setup completed")
 
-	// 4. Worker (depends on: enrollRepo)
-	enrollWorker := worker.NewEnrollmentWorker(workerQueueSize, enrollRepo)
+	// 5. Worker (depends on: enrollRepo, clock)
+	enrollJournal, err := openJournal(cfg.Worker.JournalPath, enrollRepo)
+	if err != nil {
+		return nil, fmt.Errorf("journal setup failed: %w", err)
+	}
+	clock := utils.NewKoreaTimeProvider()
+	enrollWorker := worker.NewEnrollmentWorker(cfg.Worker.QueueSize, cfg.Worker.Shards, enrollRepo, enrollJournal, clock)
 	log.Println("[info] worker setup completed")
 
-	// 5. Registration state (depends on: regConfigRepo)
-	regState, wasEnabled, err := loadRegistrationState(regConfigRepo)
+	// 6. Registration state (depends on: regConfigRepo, term)
+	regState, wasOpen, err := loadRegistrationState(regConfigRepo, term.ID)
 	if err != nil {
 		return nil, fmt.Errorf("registration state setup failed: %w", err)
 	}
-	log.Printf("[info] registration state setup completed (db_enabled: %v)", wasEnabled)
+	log.Printf("[info] registration state setup completed (phase: %s, mode: %s, db_open: %v)", regState.Phase(), regState.Mode(), wasOpen)
 
-	// 6. Services (depends on: repos, enrollWorker, regState, db)
+	// 7. Services (depends on: repos, enrollWorker, regState, clock, db)
 	warmup := func() {
 		database.WarmupConnectionPool(db, cfg.Database.PoolSize)
 	}
+	idempotencyStore := idempotency.NewStore()
 	authService := service.NewAuthService(studentRepo, cfg.Secret.AdminID, cfg.Secret.AdminPW)
-	adminService := service.NewAdminService(studentRepo, courseRepo, enrollRepo, regConfigRepo, enrollWorker, regState, warmup)
-	courseRegService := service.NewCourseRegService(courseRepo, enrollRepo, enrollWorker, regState)
+	adminService := service.NewAdminService(studentRepo, courseRepo, enrollRepo, regConfigRepo, roundRepo, cohortRepo, lotteryRepo, preferenceRepo, adminLogRepo, studentLimitRepo, specialRuleRepo, courseGroupRepo, eligibilityRepo, completionRepo, prerequisiteRepo, termRepo, enrollWorker, regState, idempotencyStore, clock, warmup)
+	courseRegService := service.NewCourseRegService(courseRepo, enrollRepo, lotteryRepo, preferenceRepo, cartRepo, enrollWorker, regState, idempotencyStore, clock)
+	if err := adminService.ReloadRounds(); err != nil {
+		return nil, fmt.Errorf("registration rounds setup failed: %w", err)
+	}
+	if err := adminService.ReloadEntryOffsets(); err != nil {
+		return nil, fmt.Errorf("cohort entry offsets setup failed: %w", err)
+	}
 	log.Println("[info] services setup completed")
 
-	// 7. Handlers (depends on: services)
+	// 8. Handlers (depends on: services)
 	handlers := &handler.Handlers{
 		Auth:      handler.NewAuthHandler(authService),
 		Admin:     handler.NewAdminHandler(adminService),
@@ -82,23 +124,37 @@ func NewApplication(cfg *setting.Config) (*Application, error) {
 	}
 	log.Println("[info] handlers setup completed")
 
-	// 8. Router (depends on: handlers)
-	router := routers.InitRouter(cfg.Server.RunMode, cfg.Secret.SessionKey, handlers)
+	// 9. Router (depends on: handlers)
+	router := routers.InitRouter(cfg.Server.RunMode, cfg.Secret.SessionKey, cfg.Worker.RequestTimeout, handlers)
 	log.Println("[info] router setup completed")
 
-	// 9. Restore registration if it was enabled before restart (depends on: adminService)
-	if wasEnabled {
+	// 10. Restore registration if it was open before restart (depends on: adminService)
+	if wasOpen {
 		log.Println("[info] restoring registration state from before restart")
 		if err := adminService.StartRegistration(); err != nil {
 			return nil, fmt.Errorf("registration restore failed: %w", err)
 		}
 	}
 
+	// 11. Registration scheduler (depends on: regState, adminService)
+	regScheduler := registration.NewScheduler(
+		regState,
+		clock,
+		registrationCheckInterval,
+		adminService.StartRegistration,
+		adminService.CloseRegistration,
+	)
+	regScheduler.Start()
+	log.Println("[info] registration scheduler started")
+
 	return &Application{
-		DB:       db,
-		Worker:   enrollWorker,
-		RegState: regState,
-		Router:   router,
+		DB:           db,
+		Worker:       enrollWorker,
+		AdminService: adminService,
+		Journal:      enrollJournal,
+		RegState:     regState,
+		RegScheduler: regScheduler,
+		Router:       router,
 	}, nil
 }
 
@@ -106,11 +162,26 @@ func NewApplication(cfg *setting.Config) (*Application, error) {
 func (app *Application) Shutdown() error {
 	log.Println("[info] shutting down application")
 
+	// Stop scheduler first so it cannot reopen registration during shutdown
+	if app.RegScheduler != nil {
+		app.RegScheduler.Stop()
+	}
+
 	// Stop worker if running
-	if app.Worker != nil && app.RegState != nil && app.RegState.IsEnabled() {
+	if app.Worker != nil && app.RegState != nil && app.RegState.IsOpen() {
 		log.Println("[info] stopping enrollment worker")
 		app.Worker.Stop()
 	}
+	if app.AdminService != nil {
+		app.AdminService.StopDetachedWorker()
+	}
+
+	// Close journal after the worker's last flush
+	if app.Journal != nil {
+		if err := app.Journal.Close(); err != nil {
+			log.Printf("[warn] failed to close journal: %v", err)
+		}
+	}
 
 	// Close database connection
 	if app.DB != nil {
@@ -126,13 +197,54 @@ func (app *Application) Shutdown() error {
 	return nil
 }
 
-func loadRegistrationState(configRepo repository.RegistrationConfigRepositoryInterface) (*registration.State, bool, error) {
-	config, err := configRepo.GetConfig()
+// loadActiveTerm returns the active term. On first start a default term is created,
+// taking over the courses, enrollments and config stored before terms existed.
+func loadActiveTerm(termRepo repository.TermRepositoryInterface) (*models.Term, error) {
+	term, err := termRepo.FetchActiveTerm()
+	if err == nil {
+		return term, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load active term: %w", err)
+	}
+
+	term = &models.Term{Name: defaultTermName, Active: true}
+	if err := termRepo.InsertTermAdoptingRows(term); err != nil {
+		return nil, fmt.Errorf("failed to create default term: %w", err)
+	}
+	log.Printf("[info] created default term %q", term.Name)
+	return term, nil
+}
+
+// openJournal opens the enrollment journal if a path is set, and applies what an earlier run left in it.
+// If the DB is still unavailable the rows stay journaled; the worker loads them on Start and flushes them later.
+func openJournal(path string, enrollRepo repository.EnrollmentRepositoryInterface) (*journal.Journal, error) {
+	if path == "" {
+		return nil, nil
+	}
+	j, err := journal.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if pending := len(j.Pending()); pending > 0 {
+		if err := j.Flush(enrollRepo.InsertEnrollmentsIfAbsent); err != nil {
+			log.Printf("[warn] replaying %d journaled enrollments failed, keeping them for later: %v", pending, err)
+		} else {
+			log.Printf("[info] replayed %d journaled enrollments", pending)
+		}
+	}
+	log.Printf("[info] journal setup completed (path: %s)", path)
+	return j, nil
+}
+
+func loadRegistrationState(configRepo repository.RegistrationConfigRepositoryInterface, termID uint) (*registration.State, bool, error) {
+	config, err := configRepo.GetConfig(termID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			config = &models.RegistrationConfig{
-				ID:        1,
-				Enabled:   false,
+				TermID:    termID,
+				Phase:     string(registration.PhaseSetup),
+				Mode:      string(registration.ModeFCFS),
 				StartTime: "",
 				EndTime:   "",
 			}
@@ -145,7 +257,23 @@ func loadRegistrationState(configRepo repository.RegistrationConfigRepositoryInt
 		}
 	}
 
-	// Always start with disabled state; restore later via StartRegistration
-	regState := registration.NewState(false, config.StartTime, config.EndTime)
-	return regState, config.Enabled, nil
+	phase := registration.Phase(config.Phase)
+	if !phase.IsValid() {
+		return nil, false, fmt.Errorf("unknown registration phase %q", config.Phase)
+	}
+
+	mode := registration.Mode(config.Mode)
+	if !mode.IsValid() {
+		return nil, false, fmt.Errorf("unknown registration mode %q", config.Mode)
+	}
+
+	// The worker is not running yet, so an OPEN registration starts as PAUSED; restore later via StartRegistration
+	wasOpen := phase == registration.PhaseOpen
+	if wasOpen {
+		phase = registration.PhasePaused
+	}
+	regState := registration.NewState(phase, config.StartTime, config.EndTime)
+	regState.SetTerm(termID)
+	regState.SetMode(mode)
+	return regState, wasOpen, nil
 }
@@ -0,0 +1,129 @@
+package allocation
+
+import (
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/models"
+	"math/rand"
+	"sort"
+)
+
+// Outcome is the result of one lottery application
+type Outcome string
+
+const (
+	OutcomeEnrolled        Outcome = "ENROLLED"
+	OutcomeWaitlisted      Outcome = "WAITLISTED"
+	OutcomeFull            Outcome = "FULL"             // 낙첨 (정원 및 대기 마감)
+	OutcomeTimeConflict    Outcome = "TIME_CONFLICT"    // 먼저 당첨된 강의와 시간 충돌
+	OutcomeLimitExceeded   Outcome = "LIMIT_EXCEEDED"   // 최대 강의 수/학점 초과
+	OutcomeNotEligible     Outcome = "NOT_ELIGIBLE"     // 수강 대상이 아님
+	OutcomePrerequisite    Outcome = "PREREQUISITE"     // 선수 과목 미이수
+	OutcomeGroupConflict   Outcome = "GROUP_CONFLICT"   // 같은 배타 그룹의 강의에 이미 당첨
+	OutcomeCorequisite     Outcome = "COREQUISITE"      // 공동 수강 강의는 추첨 대상이 아님
+	OutcomeAlreadyEnrolled Outcome = "ALREADY_ENROLLED" // 이미 수강 중이거나 대기 중
+	OutcomeInvalid         Outcome = "INVALID"          // 존재하지 않는 학생/강의
+)
+
+// Application is a student's request for a seat in a course
+type Application struct {
+	StudentID uint
+	CourseID  uint
+}
+
+// Draw is the outcome of one application
+type Draw struct {
+	StudentID uint
+	CourseID  uint
+	Order     int // 0-based draw order within the course
+	Outcome   Outcome
+}
+
+// LotteryOptions configures a lottery draw
+type LotteryOptions struct {
+	Seed           int64
+	WaitlistLosers bool // losers go to the waitlist in draw order while it has room
+}
+
+// LotteryResult holds every draw and the enrollment rows to write
+type LotteryResult struct {
+	Draws       []Draw
+	Enrollments []models.Enrollment
+}
+
+// RunLottery draws seats course by course (in course ID order). Each course's applicants are
+// shuffled with a seeded RNG after sorting by student ID, so the same seed and inputs always
+// give the same result. The cache holds existing enrollments and is updated as seats are won,
+// so a later course is never won if it conflicts with an earlier win.
+// Courses with co-requisites are never drawn, since seats are drawn one course at a time.
+func RunLottery(c *cache.EnrollmentCache, applications []Application, opts LotteryOptions) LotteryResult {
+	applicants := make(map[uint][]uint)
+	for _, app := range applications {
+		applicants[app.CourseID] = append(applicants[app.CourseID], app.StudentID)
+	}
+
+	courseIDs := make([]uint, 0, len(applicants))
+	for courseID := range applicants {
+		courseIDs = append(courseIDs, courseID)
+	}
+	sort.Slice(courseIDs, func(i, j int) bool { return courseIDs[i] < courseIDs[j] })
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	var result LotteryResult
+
+	for _, courseID := range courseIDs {
+		students := applicants[courseID]
+		sort.Slice(students, func(i, j int) bool { return students[i] < students[j] })
+		rng.Shuffle(len(students), func(i, j int) { students[i], students[j] = students[j], students[i] })
+
+		for order, studentID := range students {
+			outcome, enrollment := drawSeat(c, studentID, courseID, opts.WaitlistLosers)
+			result.Draws = append(result.Draws, Draw{StudentID: studentID, CourseID: courseID, Order: order, Outcome: outcome})
+			if enrollment != nil {
+				result.Enrollments = append(result.Enrollments, *enrollment)
+			}
+		}
+	}
+
+	return result
+}
+
+// drawSeat decides one application against the cache and applies the outcome to it
+func drawSeat(c *cache.EnrollmentCache, studentID, courseID uint, waitlistLosers bool) (Outcome, *models.Enrollment) {
+	if !c.CourseExists(courseID) || !c.StudentExists(studentID) {
+		return OutcomeInvalid, nil
+	}
+	if c.IsStudentEnrolled(studentID, courseID) || c.IsStudentWaiting(studentID, courseID) {
+		return OutcomeAlreadyEnrolled, nil
+	}
+	if c.HasTimeConflict(studentID, courseID) {
+		return OutcomeTimeConflict, nil
+	}
+	if c.HasCorequisites(courseID) {
+		return OutcomeCorequisite, nil
+	}
+	if c.HasExclusiveConflict(studentID, courseID) {
+		return OutcomeGroupConflict, nil
+	}
+	if _, missing := c.MissingPrerequisite(studentID, courseID); missing {
+		return OutcomePrerequisite, nil
+	}
+	if !c.IsEligibleForCourse(studentID, courseID) {
+		return OutcomeNotEligible, nil
+	}
+	if c.ExceedsCourseLimit(studentID) || c.ExceedsCreditLimit(studentID, courseID) || c.ExceedsSpecialLimit(studentID, courseID) {
+		return OutcomeLimitExceeded, nil
+	}
+
+	if pos, err := c.GetPosIfNotFull(courseID); err == nil {
+		c.EnrollStudent(studentID, courseID)
+		return OutcomeEnrolled, &models.Enrollment{TermID: c.TermID, StudentID: studentID, CourseID: courseID, Position: pos}
+	}
+
+	if waitlistLosers && !c.IsWaitlistFull(courseID) {
+		pos := int(c.WaitingCount[courseID].Load())
+		c.AddToWaitlist(studentID, courseID)
+		return OutcomeWaitlisted, &models.Enrollment{TermID: c.TermID, StudentID: studentID, CourseID: courseID, Position: pos, IsWaitlist: true}
+	}
+
+	return OutcomeFull, nil
+}
@@ -0,0 +1,94 @@
+package allocation
+
+import (
+	"reflect"
+	"testing"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/models"
+)
+
+func newTestCache(t *testing.T, numStudents int, courses []models.Course) *cache.EnrollmentCache {
+	t.Helper()
+	students := make([]models.Student, numStudents)
+	for i := range students {
+		students[i] = models.Student{ID: uint(i + 1)}
+	}
+	c, err := cache.NewEnrollmentCache(cache.InitData{Students: students, Courses: courses})
+	if err != nil {
+		t.Fatalf("new cache: %v", err)
+	}
+	return c
+}
+
+func TestRunLottery(t *testing.T) {
+	courses := []models.Course{
+		{ID: 1, Capacity: 2, Schedules: "월 09:00~10:00"},
+		{ID: 2, Capacity: 5, Schedules: "월 09:30~10:30"}, // conflicts with 1
+	}
+	var applications []Application
+	for studentID := uint(1); studentID <= 6; studentID++ {
+		applications = append(applications, Application{StudentID: studentID, CourseID: 1})
+		applications = append(applications, Application{StudentID: studentID, CourseID: 2})
+	}
+	opts := LotteryOptions{Seed: 42, WaitlistLosers: true}
+
+	result := RunLottery(newTestCache(t, 6, courses), applications, opts)
+
+	t.Run("reproducible", func(t *testing.T) {
+		again := RunLottery(newTestCache(t, 6, courses), applications, opts)
+		if !reflect.DeepEqual(result, again) {
+			t.Errorf("same seed produced different results")
+		}
+	})
+
+	outcomes := make(map[uint]map[Outcome]int)
+	winners := make(map[uint]bool)
+	for _, draw := range result.Draws {
+		if outcomes[draw.CourseID] == nil {
+			outcomes[draw.CourseID] = make(map[Outcome]int)
+		}
+		outcomes[draw.CourseID][draw.Outcome]++
+		if draw.CourseID == 1 && draw.Outcome == OutcomeEnrolled {
+			winners[draw.StudentID] = true
+		}
+	}
+
+	t.Run("capacity and waitlist", func(t *testing.T) {
+		if got := outcomes[1][OutcomeEnrolled]; got != 2 {
+			t.Errorf("course 1 enrolled: got %d, want 2", got)
+		}
+		if got := outcomes[1][OutcomeWaitlisted]; got != 2 {
+			t.Errorf("course 1 waitlisted: got %d, want 2 (waitlist capacity)", got)
+		}
+		if got := outcomes[1][OutcomeFull]; got != 2 {
+			t.Errorf("course 1 full: got %d, want 2", got)
+		}
+	})
+
+	t.Run("no time conflicts", func(t *testing.T) {
+		for _, draw := range result.Draws {
+			if draw.CourseID != 2 {
+				continue
+			}
+			if winners[draw.StudentID] && draw.Outcome != OutcomeTimeConflict {
+				t.Errorf("student %d won course 1 but got %s for conflicting course 2", draw.StudentID, draw.Outcome)
+			}
+			if !winners[draw.StudentID] && draw.Outcome != OutcomeEnrolled {
+				t.Errorf("student %d should have won course 2, got %s", draw.StudentID, draw.Outcome)
+			}
+		}
+	})
+
+	t.Run("waitlist positions follow draw order", func(t *testing.T) {
+		pos := 0
+		for _, enrollment := range result.Enrollments {
+			if enrollment.IsWaitlist {
+				if enrollment.Position != pos {
+					t.Errorf("waitlist position: got %d, want %d", enrollment.Position, pos)
+				}
+				pos++
+			}
+		}
+	})
+}
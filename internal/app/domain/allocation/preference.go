@@ -0,0 +1,126 @@
+package allocation
+
+import (
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/models"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/rand"
+	"sort"
+)
+
+// Preference is a student's ranked list of courses (most wanted first)
+type Preference struct {
+	StudentID  uint
+	CourseIDs  []uint
+	MaxCourses int
+}
+
+// AssignedCourse is a course given to a student with its rank in the student's list
+type AssignedCourse struct {
+	CourseID uint `json:"course_id"`
+	Rank     int  `json:"rank"` // 1-based
+}
+
+// Assignment is what one student gets; Priority is the student's 0-based place in the random order
+type Assignment struct {
+	StudentID uint             `json:"student_id"`
+	Priority  int              `json:"priority"`
+	Courses   []AssignedCourse `json:"courses"`
+}
+
+// PreferenceResult holds the assignment of every student and the enrollment rows to write.
+// Checksum identifies the rows, so a commit can check that it writes exactly what was previewed.
+type PreferenceResult struct {
+	Seed        int64               `json:"seed"`
+	Checksum    string              `json:"checksum"`
+	Assignments []Assignment        `json:"assignments"`
+	Enrollments []models.Enrollment `json:"-"`
+}
+
+// AssignByPreference runs a round-robin serial dictatorship: students are put in a random order
+// (seeded, after sorting by student ID), then in each pass every student in that order takes their
+// highest-ranked course that still has a seat and does not conflict with what they already have.
+// Passes repeat until no one can take another course, so each student gets at most one course per
+// pass before anyone gets their next one. MaxCourses caps the courses assigned in this run;
+// the global course and credit limits cap the student's total.
+func AssignByPreference(c *cache.EnrollmentCache, preferences []Preference, seed int64) PreferenceResult {
+	order := make([]Preference, len(preferences))
+	copy(order, preferences)
+	sort.Slice(order, func(i, j int) bool { return order[i].StudentID < order[j].StudentID })
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+	result := PreferenceResult{Seed: seed, Assignments: make([]Assignment, len(order))}
+	next := make([]int, len(order)) // index of the next course to consider in each student's list
+	for i, pref := range order {
+		result.Assignments[i] = Assignment{StudentID: pref.StudentID, Priority: i, Courses: []AssignedCourse{}}
+	}
+
+	for assigned := true; assigned; {
+		assigned = false
+		for i, pref := range order {
+			if len(result.Assignments[i].Courses) >= pref.MaxCourses {
+				continue
+			}
+			for next[i] < len(pref.CourseIDs) {
+				courseID := pref.CourseIDs[next[i]]
+				next[i]++
+				if pos, ok := takeSeat(c, pref.StudentID, courseID); ok {
+					result.Assignments[i].Courses = append(result.Assignments[i].Courses, AssignedCourse{CourseID: courseID, Rank: next[i]})
+					result.Enrollments = append(result.Enrollments, models.Enrollment{TermID: c.TermID, StudentID: pref.StudentID, CourseID: courseID, Position: pos})
+					assigned = true
+					break
+				}
+			}
+		}
+	}
+
+	result.Checksum = checksum(result.Assignments, result.Enrollments)
+	return result
+}
+
+func checksum(assignments []Assignment, enrollments []models.Enrollment) string {
+	// Marshalling plain structs cannot fail
+	data, _ := json.Marshal(struct {
+		Assignments []Assignment
+		Enrollments []models.Enrollment
+	}{assignments, enrollments})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// takeSeat enrolls the student in the cache if the course has a seat and fits the student's timetable and limits.
+// Courses that fail are never retried: seats only decrease and the student's timetable only grows.
+func takeSeat(c *cache.EnrollmentCache, studentID, courseID uint) (int, bool) {
+	if !c.CourseExists(courseID) || !c.StudentExists(studentID) {
+		return 0, false
+	}
+	// A student on the waitlist keeps their place there, as in the lottery
+	if c.IsStudentEnrolled(studentID, courseID) || c.IsStudentWaiting(studentID, courseID) {
+		return 0, false
+	}
+	if c.HasTimeConflict(studentID, courseID) {
+		return 0, false
+	}
+	// Courses with co-requisites are skipped like in the lottery
+	if c.HasCorequisites(courseID) || c.HasExclusiveConflict(studentID, courseID) {
+		return 0, false
+	}
+	if _, missing := c.MissingPrerequisite(studentID, courseID); missing {
+		return 0, false
+	}
+	if !c.IsEligibleForCourse(studentID, courseID) {
+		return 0, false
+	}
+	if c.ExceedsCourseLimit(studentID) || c.ExceedsCreditLimit(studentID, courseID) || c.ExceedsSpecialLimit(studentID, courseID) {
+		return 0, false
+	}
+	pos, err := c.GetPosIfNotFull(courseID)
+	if err != nil {
+		return 0, false
+	}
+	c.EnrollStudent(studentID, courseID)
+	return pos, true
+}
@@ -0,0 +1,117 @@
+package allocation
+
+import (
+	"reflect"
+	"testing"
+
+	"course-reg/internal/app/models"
+)
+
+func TestAssignByPreference(t *testing.T) {
+	courses := []models.Course{
+		{ID: 1, Capacity: 1, Schedules: "월 09:00~10:00"},
+		{ID: 2, Capacity: 2, Schedules: "월 09:30~10:30"}, // conflicts with 1
+		{ID: 3, Capacity: 3, Schedules: "화 09:00~10:00"},
+	}
+	preferences := []Preference{
+		{StudentID: 1, CourseIDs: []uint{1, 2, 3}, MaxCourses: 2},
+		{StudentID: 2, CourseIDs: []uint{1, 3, 2}, MaxCourses: 2},
+		{StudentID: 3, CourseIDs: []uint{1, 3}, MaxCourses: 1},
+	}
+
+	result := AssignByPreference(newTestCache(t, 3, courses), preferences, 7)
+
+	t.Run("reproducible", func(t *testing.T) {
+		again := AssignByPreference(newTestCache(t, 3, courses), preferences, 7)
+		if !reflect.DeepEqual(result, again) {
+			t.Errorf("same seed produced different results")
+		}
+
+		// Fewer seats change the assignment, so a commit can tell it is not the previewed one
+		smaller := append([]models.Course(nil), courses...)
+		smaller[2].Capacity = 1
+		if changed := AssignByPreference(newTestCache(t, 3, smaller), preferences, 7); changed.Checksum == result.Checksum {
+			t.Errorf("different assignments have the same checksum")
+		}
+	})
+
+	enrolled := make(map[uint]int)
+	timetables := make(map[uint][]uint)
+	for _, assignment := range result.Assignments {
+		var pref Preference
+		for _, p := range preferences {
+			if p.StudentID == assignment.StudentID {
+				pref = p
+			}
+		}
+		if len(assignment.Courses) > pref.MaxCourses {
+			t.Errorf("student %d got %d courses, max %d", assignment.StudentID, len(assignment.Courses), pref.MaxCourses)
+		}
+		for _, course := range assignment.Courses {
+			enrolled[course.CourseID]++
+			timetables[assignment.StudentID] = append(timetables[assignment.StudentID], course.CourseID)
+			if pref.CourseIDs[course.Rank-1] != course.CourseID {
+				t.Errorf("student %d: rank %d is course %d, not %d", assignment.StudentID, course.Rank, pref.CourseIDs[course.Rank-1], course.CourseID)
+			}
+		}
+	}
+
+	t.Run("capacity", func(t *testing.T) {
+		for _, course := range courses {
+			if enrolled[course.ID] > course.Capacity {
+				t.Errorf("course %d: %d enrolled, capacity %d", course.ID, enrolled[course.ID], course.Capacity)
+			}
+		}
+	})
+
+	t.Run("no time conflicts", func(t *testing.T) {
+		for studentID, courseIDs := range timetables {
+			has := make(map[uint]bool)
+			for _, courseID := range courseIDs {
+				has[courseID] = true
+			}
+			if has[1] && has[2] {
+				t.Errorf("student %d got conflicting courses 1 and 2", studentID)
+			}
+		}
+	})
+
+	t.Run("top choice goes to highest priority", func(t *testing.T) {
+		first := result.Assignments[0]
+		if len(first.Courses) == 0 || first.Courses[0].CourseID != 1 {
+			t.Errorf("student %d with priority 0 should get course 1, got %+v", first.StudentID, first.Courses)
+		}
+	})
+
+	t.Run("fills every fitting seat", func(t *testing.T) {
+		if len(result.Enrollments) != 5 {
+			t.Errorf("enrollments: got %d, want 5", len(result.Enrollments))
+		}
+	})
+}
+
+func TestAssignByPreferenceSkipsWaitingStudent(t *testing.T) {
+	courses := []models.Course{
+		{ID: 1, Capacity: 2, Schedules: "월 09:00~10:00"},
+		{ID: 2, Capacity: 2, Schedules: "화 09:00~10:00"},
+	}
+	c := newTestCache(t, 2, courses)
+	c.AddToWaitlist(1, 1)
+
+	result := AssignByPreference(c, []Preference{
+		{StudentID: 1, CourseIDs: []uint{1, 2}, MaxCourses: 2},
+		{StudentID: 2, CourseIDs: []uint{1}, MaxCourses: 1},
+	}, 7)
+
+	for _, enrollment := range result.Enrollments {
+		if enrollment.StudentID == 1 && enrollment.CourseID == 1 {
+			t.Errorf("waiting student 1 was also enrolled in course 1: %+v", enrollment)
+		}
+	}
+	if !c.IsStudentWaiting(1, 1) || c.IsStudentEnrolled(1, 1) {
+		t.Errorf("student 1 should stay on the waitlist of course 1 only")
+	}
+	if len(result.Enrollments) != 2 {
+		t.Errorf("enrollments: got %d, want 2 (student 1 in course 2, student 2 in course 1)", len(result.Enrollments))
+	}
+}
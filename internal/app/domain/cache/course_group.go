@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"course-reg/internal/app/models"
+	"slices"
+)
+
+// Course group types
+const (
+	GroupExclusive   = "EXCLUSIVE"   // at most one course of the group
+	GroupCorequisite = "COREQUISITE" // all courses of the group or none
+)
+
+// IsValidGroupType checks if t is a known course group type
+func IsValidGroupType(t string) bool {
+	return t == GroupExclusive || t == GroupCorequisite
+}
+
+// loadCourseGroups builds the exclusive graph and the co-requisite sets.
+// Co-requisite groups sharing a course are merged, so taking any course means taking the whole set.
+// Must be called after loadInitCourses; unknown courses are ignored.
+func (cache *EnrollmentCache) loadCourseGroups(groups []models.CourseGroup) {
+	for _, group := range groups {
+		var courseIDs []uint
+		for _, courseID := range group.CourseIDs {
+			if cache.CourseExists(courseID) && !slices.Contains(courseIDs, courseID) {
+				courseIDs = append(courseIDs, courseID)
+			}
+		}
+
+		switch group.Type {
+		case GroupExclusive:
+			for _, c1 := range courseIDs {
+				for _, c2 := range courseIDs {
+					if c1 == c2 {
+						continue
+					}
+					if cache.ExclusiveGraph[c1] == nil {
+						cache.ExclusiveGraph[c1] = make(map[uint]bool)
+					}
+					cache.ExclusiveGraph[c1][c2] = true
+				}
+			}
+		case GroupCorequisite:
+			set := courseIDs
+			for _, courseID := range courseIDs {
+				for _, partner := range cache.Corequisites[courseID] {
+					if !slices.Contains(set, partner) {
+						set = append(set, partner)
+					}
+				}
+			}
+			slices.Sort(set)
+			for _, courseID := range set {
+				cache.Corequisites[courseID] = slices.DeleteFunc(slices.Clone(set), func(id uint) bool { return id == courseID })
+			}
+		}
+	}
+}
+
+// HasExclusiveConflict checks if the student is enrolled in another course of an exclusive group of courseID,
+// not counting the courses in except
+// Assumes student existence is already validated
+func (cache *EnrollmentCache) HasExclusiveConflict(studentID, courseID uint, except ...uint) bool {
+	for enrolledCourse := range cache.StudentCourses[studentID] {
+		if cache.ExclusiveGraph[courseID][enrolledCourse] && !slices.Contains(except, enrolledCourse) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCorequisites checks if a course must be taken together with other courses
+func (cache *EnrollmentCache) HasCorequisites(courseID uint) bool {
+	return len(cache.Corequisites[courseID]) > 0
+}
+
+// MissingCorequisites returns the co-requisites of courseID the student is not enrolled in, in course ID order
+// Assumes student existence is already validated
+func (cache *EnrollmentCache) MissingCorequisites(studentID, courseID uint) []uint {
+	var missing []uint
+	for _, partner := range cache.Corequisites[courseID] {
+		if !cache.IsStudentEnrolled(studentID, partner) {
+			missing = append(missing, partner)
+		}
+	}
+	return missing
+}
+
+// EnrolledCorequisites returns the co-requisites of courseID the student is enrolled in, in course ID order
+// Assumes student existence is already validated
+func (cache *EnrollmentCache) EnrolledCorequisites(studentID, courseID uint) []uint {
+	var enrolled []uint
+	for _, partner := range cache.Corequisites[courseID] {
+		if cache.IsStudentEnrolled(studentID, partner) {
+			enrolled = append(enrolled, partner)
+		}
+	}
+	return enrolled
+}
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"course-reg/internal/app/models"
 	"fmt"
 	"strconv"
 	"strings"
@@ -62,6 +63,28 @@ func parseCourseSchedule(schedules string) ([]courseTime, error) {
 	return slots, nil
 }
 
+var scheduleDays = map[string]bool{"월": true, "화": true, "수": true, "목": true, "금": true, "토": true, "일": true}
+
+// ValidateSchedule checks a schedule string more strictly than the conflict check needs:
+// every slot must have a known day and a time range within one day that ends after it starts
+func ValidateSchedule(schedules string) error {
+	slots, err := parseCourseSchedule(schedules)
+	if err != nil {
+		return err
+	}
+	for _, slot := range slots {
+		if !scheduleDays[slot.Day] {
+			return fmt.Errorf("unknown day %q", slot.Day)
+		}
+		start := slot.StartHour*60 + slot.StartMin
+		end := slot.EndHour*60 + slot.EndMin
+		if start < 0 || slot.StartMin >= 60 || slot.EndMin >= 60 || end > 24*60 || start >= end {
+			return fmt.Errorf("invalid time range %02d:%02d~%02d:%02d", slot.StartHour, slot.StartMin, slot.EndHour, slot.EndMin)
+		}
+	}
+	return nil
+}
+
 // hasCourseTimeConflict checks if two time slots conflict
 func hasCourseTimeConflict(slot1, slot2 courseTime) bool {
 	// Different days - no conflict
@@ -80,6 +103,14 @@ func hasCourseTimeConflict(slot1, slot2 courseTime) bool {
 	return start1 < end2 && start2 < end1
 }
 
+// CoursesConflict checks if two courses' schedules overlap, using the same rule as the ConflictGraph
+func CoursesConflict(course1, course2 models.Course) (bool, error) {
+	if course1.ID == course2.ID {
+		return false, nil
+	}
+	return hasCourseScheduleConflict(course1.Schedules, course2.Schedules)
+}
+
 // hasCourseScheduleConflict checks if two schedule strings conflict
 func hasCourseScheduleConflict(schedule1, schedule2 string) (bool, error) {
 	slots1, err := parseCourseSchedule(schedule1)
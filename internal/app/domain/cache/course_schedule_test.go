@@ -228,3 +228,28 @@ func TestHasCourseScheduleConflict(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateSchedule(t *testing.T) {
+	valid := []string{"월 09:10~11:30", "월 09:10~11:30, 수 17:10~19:20", "일 00:00~24:00"}
+	for _, input := range valid {
+		if err := ValidateSchedule(input); err != nil {
+			t.Errorf("%q: unexpected error: %v", input, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"월 9:10~11:30",  // wrong length
+		"망 09:10~11:30", // unknown day
+		"월 11:30~09:10", // ends before it starts
+		"월 09:10~09:10", // empty range
+		"월 09:70~11:30", // minute out of range
+		"월 23:00~25:00", // past midnight
+		"월 -1:00~11:30", // negative hour
+	}
+	for _, input := range invalid {
+		if err := ValidateSchedule(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/eligibility"
+	"time"
+)
+
+// loadEligibility builds the eligibility engine from the stored rules and the students' attributes
+// Must be called after loadCompletions
+func (cache *EnrollmentCache) loadEligibility(data InitData) error {
+	students := make(map[uint]*eligibility.Student, len(data.Students))
+	for _, s := range data.Students {
+		students[s.ID] = &eligibility.Student{
+			ID:        s.ID,
+			BirthDate: eligibility.ParseBirthDate(s.BirthDate),
+			Groups:    make(map[string]struct{}),
+			Completed: cache.StudentCompleted[s.ID],
+		}
+	}
+	for _, m := range data.StudentGroups {
+		if s, ok := students[m.StudentID]; ok {
+			s.Groups[m.GroupName] = struct{}{}
+		}
+	}
+
+	asOf := data.EligibilityDate
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	engine, err := eligibility.NewEngine(data.EligibilityRules, students, asOf)
+	if err != nil {
+		return err
+	}
+	cache.Eligibility = engine
+	return nil
+}
+
+// CheckEligibility checks the special course eligibility list and the course's eligibility rules.
+// Rule violations are returned as *eligibility.NotEligibleError with the reason.
+// Assumes student and course existence is already validated
+func (cache *EnrollmentCache) CheckEligibility(studentID, courseID uint) error {
+	if !cache.isOnSpecialList(studentID, courseID) {
+		return e.ErrNotEligibleForCourse
+	}
+	return cache.Eligibility.Check(studentID, courseID)
+}
+
+// IsEligibleForCourse checks if the student may enroll in the course
+// Assumes student and course existence is already validated
+func (cache *EnrollmentCache) IsEligibleForCourse(studentID, courseID uint) bool {
+	return cache.CheckEligibility(studentID, courseID) == nil
+}
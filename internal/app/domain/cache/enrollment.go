@@ -1,39 +1,111 @@
 package cache
 
 import (
+	"course-reg/internal/app/domain/eligibility"
 	"course-reg/internal/app/models"
 	"errors"
 	"fmt"
+	"sort"
 	"sync/atomic"
+	"time"
 )
 
 // EnrollmentCache is a simple in-memory data structure
 type EnrollmentCache struct {
+	TermID uint // term the courses and enrollments belong to; new enrollment rows are written to it
 
 	// Course data
 	CourseCapacity map[uint]int           // courseID -> capacity
 	ConflictGraph  map[uint]map[uint]bool // courseID -> conflicting courseIDs
+	ExclusiveGraph map[uint]map[uint]bool // courseID -> courseIDs sharing an exclusive group
+	Corequisites   map[uint][]uint        // courseID -> courseIDs that must be taken with it
 
 	// Enrollment data (atomic count-based)
 	StudentCourses        map[uint]map[uint]struct{} // studentID -> set of enrolled courseIDs
 	StudentWaitingCourses map[uint]map[uint]struct{} // studentID -> set of waiting courseIDs
 	EnrolledCount         map[uint]*atomic.Int32     // courseID -> count of enrolled students (atomic)
 	WaitingCount          map[uint]*atomic.Int32     // courseID -> count of waiting students (atomic)
+	CourseWaitlist        map[uint][]uint            // courseID -> waiting studentIDs ordered by position
+
+	// Seat holds; held seats count against capacity but are not enrollments. Holds are not persisted.
+	HeldCount    map[uint]*atomic.Int32      // courseID -> count of held seats (atomic)
+	StudentHolds map[uint]map[uint]time.Time // studentID -> courseID -> hold expiry
+
+	// Limit data
+	CourseCredits map[uint]int   // courseID -> credits
+	DefaultLimit  Limit          // applies to students without an override
+	StudentLimits map[uint]Limit // studentID -> limit override
+
+	// Special course data
+	SpecialCourses    map[uint]struct{}          // set of special courseIDs
+	SpecialEligible   map[uint]map[uint]struct{} // courseID -> eligible studentIDs (only courses with a rule)
+	MaxSpecialCourses int                        // 0 means no limit
+
+	// Eligibility rules and the student attributes they are checked against
+	Eligibility *eligibility.Engine
+
+	// Prerequisite data
+	StudentCompleted map[uint]map[string]struct{} // studentID -> set of course codes completed in previous terms
+	Prerequisites    map[uint][]string            // courseID -> course codes that must be completed first
 }
 
-func NewEnrollmentCacheWithData(students []models.Student, courses []models.Course, enrollments []models.Enrollment) (*EnrollmentCache, error) {
+// InitData is everything the cache is loaded from
+type InitData struct {
+	TermID        uint
+	Students      []models.Student
+	Courses       []models.Course
+	Enrollments   []models.Enrollment
+	CourseGroups  []models.CourseGroup
+	DefaultLimit  Limit
+	StudentLimits map[uint]Limit
+
+	SpecialRules      []models.SpecialCourseRule
+	MaxSpecialCourses int
+
+	EligibilityRules []models.EligibilityRule
+	StudentGroups    []models.StudentGroupMember
+	Completions      []models.CourseCompletion
+	Prerequisites    []models.CoursePrerequisite
+	EligibilityDate  time.Time // ages are computed on this date; now if zero
+}
+
+func NewEnrollmentCache(data InitData) (*EnrollmentCache, error) {
 	cache := &EnrollmentCache{
 		CourseCapacity:        make(map[uint]int),
 		ConflictGraph:         make(map[uint]map[uint]bool),
+		ExclusiveGraph:        make(map[uint]map[uint]bool),
+		Corequisites:          make(map[uint][]uint),
 		StudentCourses:        make(map[uint]map[uint]struct{}),
 		StudentWaitingCourses: make(map[uint]map[uint]struct{}),
 		EnrolledCount:         make(map[uint]*atomic.Int32),
 		WaitingCount:          make(map[uint]*atomic.Int32),
+		CourseWaitlist:        make(map[uint][]uint),
+		HeldCount:             make(map[uint]*atomic.Int32),
+		StudentHolds:          make(map[uint]map[uint]time.Time),
+		CourseCredits:         make(map[uint]int),
+		TermID:                data.TermID,
+		DefaultLimit:          data.DefaultLimit,
+		StudentLimits:         data.StudentLimits,
+		SpecialCourses:        make(map[uint]struct{}),
+		SpecialEligible:       make(map[uint]map[uint]struct{}),
+		MaxSpecialCourses:     data.MaxSpecialCourses,
+		StudentCompleted:      make(map[uint]map[string]struct{}),
+		Prerequisites:         make(map[uint][]string),
+	}
+	if cache.StudentLimits == nil {
+		cache.StudentLimits = make(map[uint]Limit)
 	}
-	cache.loadInitStudents(students)
-	cache.loadInitCourses(courses)
-	cache.loadEnrollments(enrollments)
-	if err := cache.buildConflictGraph(courses); err != nil {
+	cache.loadInitStudents(data.Students)
+	cache.loadInitCourses(data.Courses)
+	cache.loadEnrollments(data.Enrollments)
+	cache.loadSpecialCourses(data.Students, data.Courses, data.SpecialRules)
+	cache.loadCourseGroups(data.CourseGroups)
+	cache.loadCompletions(data.Completions)
+	cache.loadPrerequisites(data.Courses, data.Prerequisites)
+	if err := cache.loadEligibility(data); err != nil {
+		return nil, err
+	}
+	if err := cache.buildConflictGraph(data.Courses); err != nil {
 		return nil, err
 	}
 	return cache, nil
@@ -43,37 +115,39 @@ func (cache *EnrollmentCache) loadInitStudents(students []models.Student) {
 	for _, s := range students {
 		cache.StudentCourses[s.ID] = make(map[uint]struct{})
 		cache.StudentWaitingCourses[s.ID] = make(map[uint]struct{})
+		cache.StudentCompleted[s.ID] = make(map[string]struct{})
 	}
 }
 
 func (cache *EnrollmentCache) loadInitCourses(courses []models.Course) {
 	for _, c := range courses {
 		cache.CourseCapacity[c.ID] = c.Capacity
+		cache.CourseCredits[c.ID] = c.Credits
 		cache.EnrolledCount[c.ID] = &atomic.Int32{}
 		cache.WaitingCount[c.ID] = &atomic.Int32{}
+		cache.HeldCount[c.ID] = &atomic.Int32{}
 	}
 }
 
 // loadEnrollments loads existing enrollments into cache
 // Must be called after LoadInitStudents and LoadInitCourses
+// Counts are derived from the number of rows (not positions), since cancellations leave gaps in positions.
 func (cache *EnrollmentCache) loadEnrollments(enrollments []models.Enrollment) {
+	var waiting []models.Enrollment
 	for _, e := range enrollments {
 		if e.IsWaitlist {
-			// Update to max(current, position + 1)
-			currentMax := cache.WaitingCount[e.CourseID].Load()
-			if int32(e.Position+1) > currentMax {
-				cache.WaitingCount[e.CourseID].Store(int32(e.Position + 1))
-			}
-			cache.StudentWaitingCourses[e.StudentID][e.CourseID] = struct{}{}
+			waiting = append(waiting, e)
 		} else {
-			// Update to max(current, position + 1)
-			currentMax := cache.EnrolledCount[e.CourseID].Load()
-			if int32(e.Position+1) > currentMax {
-				cache.EnrolledCount[e.CourseID].Store(int32(e.Position + 1))
-			}
+			cache.EnrolledCount[e.CourseID].Add(1)
 			cache.StudentCourses[e.StudentID][e.CourseID] = struct{}{}
 		}
 	}
+
+	// Waitlist order follows the stored position
+	sort.Slice(waiting, func(i, j int) bool { return waiting[i].Position < waiting[j].Position })
+	for _, e := range waiting {
+		cache.AddToWaitlist(e.StudentID, e.CourseID)
+	}
 }
 
 func (cache *EnrollmentCache) buildConflictGraph(courses []models.Course) error {
@@ -110,6 +184,7 @@ type CourseCountInfo struct {
 	Capacity      int
 	EnrolledCount int
 	WaitingCount  int
+	HeldCount     int
 }
 
 func (cache *EnrollmentCache) GetAllCourseCountInfo() map[uint]CourseCountInfo {
@@ -119,6 +194,7 @@ func (cache *EnrollmentCache) GetAllCourseCountInfo() map[uint]CourseCountInfo {
 			Capacity:      capacity,
 			EnrolledCount: int(cache.EnrolledCount[courseID].Load()),
 			WaitingCount:  int(cache.WaitingCount[courseID].Load()),
+			HeldCount:     int(cache.HeldCount[courseID].Load()),
 		}
 	}
 	return info
@@ -142,10 +218,23 @@ func (cache *EnrollmentCache) HasTimeConflict(studentID, courseID uint) bool {
 	return false
 }
 
+// HasTimeConflictExcept checks for a time conflict as if the student had already dropped exceptCourseID
+// Assumes student existence is already validated
+func (cache *EnrollmentCache) HasTimeConflictExcept(studentID, courseID, exceptCourseID uint) bool {
+	for enrolledCourse := range cache.StudentCourses[studentID] {
+		if enrolledCourse != exceptCourseID && cache.ConflictGraph[courseID][enrolledCourse] {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPosIfNotFull returns the position of the next enrolled student, or an error if no seat is free.
+// Held seats are not free.
 func (cache *EnrollmentCache) GetPosIfNotFull(courseID uint) (int, error) {
 	capacity := cache.CourseCapacity[courseID]
 	enrolledCount := int(cache.EnrolledCount[courseID].Load())
-	if enrolledCount >= capacity {
+	if enrolledCount+int(cache.HeldCount[courseID].Load()) >= capacity {
 		return 0, errors.New("")
 	}
 	return enrolledCount, nil
@@ -166,10 +255,58 @@ func (cache *EnrollmentCache) EnrollStudent(studentID, courseID uint) {
 	cache.StudentCourses[studentID][courseID] = struct{}{}
 }
 
-// AddToWaitlist adds a student to a course's waitlist and returns their position
+// TakeSeat enrolls a student if a seat is free and returns their position, like GetPosIfNotFull and
+// EnrollStudent together. The seat is claimed atomically, so workers deciding different students at the
+// same time never exceed capacity.
+// Assumes nothing else changes the student's courses or the course's holds meanwhile
+func (cache *EnrollmentCache) TakeSeat(studentID, courseID uint) (int, bool) {
+	capacity := int32(cache.CourseCapacity[courseID])
+	enrolledCount := cache.EnrolledCount[courseID]
+	for {
+		enrolled := enrolledCount.Load()
+		if enrolled+cache.HeldCount[courseID].Load() >= capacity {
+			return 0, false
+		}
+		if enrolledCount.CompareAndSwap(enrolled, enrolled+1) {
+			cache.StudentCourses[studentID][courseID] = struct{}{}
+			return int(enrolled), true
+		}
+	}
+}
+
+// CancelStudent removes a student's enrollment from a course
+// Assumes the student is enrolled in the course
+func (cache *EnrollmentCache) CancelStudent(studentID, courseID uint) {
+	cache.EnrolledCount[courseID].Add(-1)
+	delete(cache.StudentCourses[studentID], courseID)
+}
+
+// IsStudentWaiting checks if a student is on a course's waitlist
+// Assumes student existence is already validated
+func (cache *EnrollmentCache) IsStudentWaiting(studentID, courseID uint) bool {
+	_, exists := cache.StudentWaitingCourses[studentID][courseID]
+	return exists
+}
+
+// AddToWaitlist adds a student to a course's waitlist and returns their position (1-based)
 // Assumes student and course existence is already validated
 func (cache *EnrollmentCache) AddToWaitlist(studentID, courseID uint) int {
 	newCount := cache.WaitingCount[courseID].Add(1)
 	cache.StudentWaitingCourses[studentID][courseID] = struct{}{}
+	cache.CourseWaitlist[courseID] = append(cache.CourseWaitlist[courseID], studentID)
 	return int(newCount)
 }
+
+// RemoveFromWaitlist removes a student from a course's waitlist; students behind move up by one
+// Assumes the student is on the waitlist
+func (cache *EnrollmentCache) RemoveFromWaitlist(studentID, courseID uint) {
+	waitlist := cache.CourseWaitlist[courseID]
+	for i, id := range waitlist {
+		if id == studentID {
+			cache.CourseWaitlist[courseID] = append(waitlist[:i], waitlist[i+1:]...)
+			break
+		}
+	}
+	cache.WaitingCount[courseID].Add(-1)
+	delete(cache.StudentWaitingCourses[studentID], courseID)
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"course-reg/internal/app/models"
+)
+
+func TestLoadEnrollments(t *testing.T) {
+	students := []models.Student{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	courses := []models.Course{
+		{ID: 10, Capacity: 2, Schedules: "월 09:00~10:00"},
+	}
+	// positions have gaps left by cancellations, and waitlist rows arrive out of order
+	enrollments := []models.Enrollment{
+		{StudentID: 1, CourseID: 10, Position: 1},
+		{StudentID: 2, CourseID: 10, Position: 3},
+		{StudentID: 4, CourseID: 10, Position: 1, IsWaitlist: true},
+		{StudentID: 3, CourseID: 10, Position: 0, IsWaitlist: true},
+	}
+
+	cache, err := NewEnrollmentCache(InitData{Students: students, Courses: courses, Enrollments: enrollments})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := cache.EnrolledCount[10].Load(); got != 2 {
+		t.Errorf("enrolled count: got %d, want 2", got)
+	}
+	if got := cache.WaitingCount[10].Load(); got != 2 {
+		t.Errorf("waiting count: got %d, want 2", got)
+	}
+	waitlist := cache.CourseWaitlist[10]
+	if len(waitlist) != 2 || waitlist[0] != 3 || waitlist[1] != 4 {
+		t.Errorf("waitlist order: got %v, want [3 4]", waitlist)
+	}
+}
+
+func TestWaitlist(t *testing.T) {
+	students := []models.Student{{ID: 1}, {ID: 2}, {ID: 3}}
+	courses := []models.Course{
+		{ID: 10, Capacity: 1, Schedules: "월 09:00~10:00"},
+	}
+	cache, err := NewEnrollmentCache(InitData{Students: students, Courses: courses})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, studentID := range []uint{1, 2, 3} {
+		if pos := cache.AddToWaitlist(studentID, 10); pos != i+1 {
+			t.Errorf("student %d position: got %d, want %d", studentID, pos, i+1)
+		}
+	}
+
+	cache.RemoveFromWaitlist(2, 10)
+
+	if cache.IsStudentWaiting(2, 10) {
+		t.Errorf("student 2 should have left the waitlist")
+	}
+	if got := cache.WaitingCount[10].Load(); got != 2 {
+		t.Errorf("waiting count: got %d, want 2", got)
+	}
+	waitlist := cache.CourseWaitlist[10]
+	if len(waitlist) != 2 || waitlist[0] != 1 || waitlist[1] != 3 {
+		t.Errorf("waitlist order: got %v, want [1 3]", waitlist)
+	}
+}
+
+func TestTakeSeatConcurrent(t *testing.T) {
+	const numStudents, capacity = 100, 10
+	students := make([]models.Student, numStudents)
+	for i := range students {
+		students[i].ID = uint(i + 1)
+	}
+	courses := []models.Course{{ID: 10, Capacity: capacity, Schedules: "월 09:00~10:00"}}
+	cache, err := NewEnrollmentCache(InitData{Students: students, Courses: courses})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	positions := make(chan int, numStudents)
+	for _, s := range students {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if pos, ok := cache.TakeSeat(s.ID, 10); ok {
+				positions <- pos
+			}
+		}()
+	}
+	wg.Wait()
+	close(positions)
+
+	taken := make(map[int]bool)
+	for pos := range positions {
+		if taken[pos] {
+			t.Errorf("position %d given twice", pos)
+		}
+		taken[pos] = true
+	}
+	if len(taken) != capacity || cache.EnrolledCount[10].Load() != capacity {
+		t.Errorf("seats taken: got %d (count %d), want %d", len(taken), cache.EnrolledCount[10].Load(), capacity)
+	}
+}
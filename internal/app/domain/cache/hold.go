@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"sort"
+	"time"
+)
+
+// Hold is a seat reserved for a student until it expires
+type Hold struct {
+	StudentID uint
+	CourseID  uint
+	ExpiresAt time.Time
+}
+
+// HoldSeat reserves a seat in the course for the student until expiresAt; the seat counts against capacity
+// Assumes student and course existence is already validated and the course is not full
+func (cache *EnrollmentCache) HoldSeat(studentID, courseID uint, expiresAt time.Time) {
+	if cache.StudentHolds[studentID] == nil {
+		cache.StudentHolds[studentID] = make(map[uint]time.Time)
+	}
+	cache.StudentHolds[studentID][courseID] = expiresAt
+	cache.HeldCount[courseID].Add(1)
+}
+
+// ReleaseHold gives a held seat back
+// Assumes the student holds a seat in the course
+func (cache *EnrollmentCache) ReleaseHold(studentID, courseID uint) {
+	delete(cache.StudentHolds[studentID], courseID)
+	cache.HeldCount[courseID].Add(-1)
+}
+
+// HoldExpiry returns when the student's hold on the course expires; ok is false if there is no hold
+func (cache *EnrollmentCache) HoldExpiry(studentID, courseID uint) (expiresAt time.Time, ok bool) {
+	expiresAt, ok = cache.StudentHolds[studentID][courseID]
+	return expiresAt, ok
+}
+
+// HasHoldConflict checks if the course overlaps in time, or shares an exclusive group, with a course
+// the student is holding a seat in
+func (cache *EnrollmentCache) HasHoldConflict(studentID, courseID uint) bool {
+	for heldCourse := range cache.StudentHolds[studentID] {
+		if cache.ConflictGraph[courseID][heldCourse] || cache.ExclusiveGraph[courseID][heldCourse] {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpiredHolds returns the holds that expired at or before now, oldest first
+func (cache *EnrollmentCache) ExpiredHolds(now time.Time) []Hold {
+	var expired []Hold
+	for studentID, holds := range cache.StudentHolds {
+		for courseID, expiresAt := range holds {
+			if !expiresAt.After(now) {
+				expired = append(expired, Hold{StudentID: studentID, CourseID: courseID, ExpiresAt: expiresAt})
+			}
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		if !expired[i].ExpiresAt.Equal(expired[j].ExpiresAt) {
+			return expired[i].ExpiresAt.Before(expired[j].ExpiresAt)
+		}
+		if expired[i].CourseID != expired[j].CourseID {
+			return expired[i].CourseID < expired[j].CourseID
+		}
+		return expired[i].StudentID < expired[j].StudentID
+	})
+	return expired
+}
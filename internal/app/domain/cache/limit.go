@@ -0,0 +1,70 @@
+package cache
+
+import "slices"
+
+// Limit caps what a single student can enroll in; 0 means no limit
+type Limit struct {
+	MaxCourses int
+	MaxCredits int
+}
+
+// StudentLimit returns the student's override, or the default limit
+func (cache *EnrollmentCache) StudentLimit(studentID uint) Limit {
+	if limit, ok := cache.StudentLimits[studentID]; ok {
+		return limit
+	}
+	return cache.DefaultLimit
+}
+
+// SetStudentLimit replaces the student's override; nil removes it, so the default limit applies again.
+// Enrollments already over a lowered limit are kept.
+func (cache *EnrollmentCache) SetStudentLimit(studentID uint, limit *Limit) {
+	if limit == nil {
+		delete(cache.StudentLimits, studentID)
+		return
+	}
+	cache.StudentLimits[studentID] = *limit
+}
+
+// countedCourses returns the courses that count toward the student's limits, not counting the courses in except:
+// the enrolled ones and the ones the student holds a seat in, since a held seat is meant to become an enrollment
+func (cache *EnrollmentCache) countedCourses(studentID uint, except []uint) []uint {
+	var courseIDs []uint
+	for courseID := range cache.StudentCourses[studentID] {
+		if !slices.Contains(except, courseID) {
+			courseIDs = append(courseIDs, courseID)
+		}
+	}
+	for courseID := range cache.StudentHolds[studentID] {
+		if !slices.Contains(except, courseID) {
+			courseIDs = append(courseIDs, courseID)
+		}
+	}
+	return courseIDs
+}
+
+// ExceedsCourseLimit checks if enrolling in one more course would exceed the student's course limit,
+// counting held seats and not counting the courses in except (e.g. the course being dropped in a swap)
+// Assumes student existence is already validated
+func (cache *EnrollmentCache) ExceedsCourseLimit(studentID uint, except ...uint) bool {
+	limit := cache.StudentLimit(studentID).MaxCourses
+	if limit <= 0 {
+		return false
+	}
+	return len(cache.countedCourses(studentID, except))+1 > limit
+}
+
+// ExceedsCreditLimit checks if enrolling in courseID would exceed the student's credit limit,
+// counting held seats and not counting the courses in except
+// Assumes student and course existence is already validated
+func (cache *EnrollmentCache) ExceedsCreditLimit(studentID, courseID uint, except ...uint) bool {
+	limit := cache.StudentLimit(studentID).MaxCredits
+	if limit <= 0 {
+		return false
+	}
+	credits := cache.CourseCredits[courseID]
+	for _, counted := range cache.countedCourses(studentID, except) {
+		credits += cache.CourseCredits[counted]
+	}
+	return credits > limit
+}
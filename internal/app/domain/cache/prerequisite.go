@@ -0,0 +1,37 @@
+package cache
+
+import "course-reg/internal/app/models"
+
+// loadCompletions loads the courses each student completed in previous terms
+// Must be called after loadInitStudents; unknown students are ignored.
+func (cache *EnrollmentCache) loadCompletions(completions []models.CourseCompletion) {
+	for _, c := range completions {
+		if completed, ok := cache.StudentCompleted[c.StudentID]; ok {
+			completed[c.CourseCode] = struct{}{}
+		}
+	}
+}
+
+// loadPrerequisites applies prerequisites, declared by course code, to every course with that code
+func (cache *EnrollmentCache) loadPrerequisites(courses []models.Course, prerequisites []models.CoursePrerequisite) {
+	courseIDs := make(map[string][]uint)
+	for _, c := range courses {
+		courseIDs[c.Code] = append(courseIDs[c.Code], c.ID)
+	}
+	for _, p := range prerequisites {
+		for _, courseID := range courseIDs[p.CourseCode] {
+			cache.Prerequisites[courseID] = append(cache.Prerequisites[courseID], p.PrerequisiteCode)
+		}
+	}
+}
+
+// MissingPrerequisite returns the code of the first prerequisite of courseID the student has not completed
+// Assumes student existence is already validated
+func (cache *EnrollmentCache) MissingPrerequisite(studentID, courseID uint) (string, bool) {
+	for _, code := range cache.Prerequisites[courseID] {
+		if _, ok := cache.StudentCompleted[studentID][code]; !ok {
+			return code, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"course-reg/internal/app/models"
+	"slices"
+)
+
+// loadSpecialCourses loads which courses are special and resolves each rule into a set of eligible students
+// Must be called after loadInitStudents
+func (cache *EnrollmentCache) loadSpecialCourses(students []models.Student, courses []models.Course, rules []models.SpecialCourseRule) {
+	for _, c := range courses {
+		if c.IsSpecial {
+			cache.SpecialCourses[c.ID] = struct{}{}
+		}
+	}
+
+	for _, rule := range rules {
+		if _, ok := cache.SpecialCourses[rule.CourseID]; !ok {
+			continue
+		}
+		eligible := make(map[uint]struct{})
+		for _, studentID := range rule.StudentIDs {
+			eligible[studentID] = struct{}{}
+		}
+		for _, s := range students {
+			if s.Cohort != "" && slices.Contains(rule.Cohorts, s.Cohort) {
+				eligible[s.ID] = struct{}{}
+			}
+		}
+		cache.SpecialEligible[rule.CourseID] = eligible
+	}
+}
+
+// IsSpecialCourse checks if a course is special
+func (cache *EnrollmentCache) IsSpecialCourse(courseID uint) bool {
+	_, ok := cache.SpecialCourses[courseID]
+	return ok
+}
+
+// isOnSpecialList checks the eligibility list of a special course; other courses are open to everyone
+func (cache *EnrollmentCache) isOnSpecialList(studentID, courseID uint) bool {
+	eligible, ok := cache.SpecialEligible[courseID]
+	if !ok {
+		return true
+	}
+	_, ok = eligible[studentID]
+	return ok
+}
+
+// ExceedsSpecialLimit checks if enrolling in courseID would exceed the special course limit,
+// counting held seats and not counting the courses in except
+// Assumes student existence is already validated
+func (cache *EnrollmentCache) ExceedsSpecialLimit(studentID, courseID uint, except ...uint) bool {
+	if cache.MaxSpecialCourses <= 0 || !cache.IsSpecialCourse(courseID) {
+		return false
+	}
+	count := 1
+	for _, counted := range cache.countedCourses(studentID, except) {
+		if cache.IsSpecialCourse(counted) {
+			count++
+		}
+	}
+	return count > cache.MaxSpecialCourses
+}
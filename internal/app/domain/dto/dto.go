@@ -8,3 +8,97 @@ type SetRegistrationPeriodRequest struct {
 type EnrollCourseRequest struct {
 	CourseID uint `json:"course_id" binding:"required"`
 }
+
+type SwapCourseRequest struct {
+	FromCourseID uint `json:"from_course_id" binding:"required"`
+	ToCourseID   uint `json:"to_course_id" binding:"required"`
+}
+
+type ForceEnrollRequest struct {
+	StudentID          uint   `json:"student_id" binding:"required"`
+	CourseID           uint   `json:"course_id" binding:"required"`
+	Reason             string `json:"reason" binding:"required"`
+	IgnoreCapacity     bool   `json:"ignore_capacity"`
+	IgnoreTimeConflict bool   `json:"ignore_time_conflict"`
+	IgnoreLimits       bool   `json:"ignore_limits"`
+	BypassEligibility  bool   `json:"bypass_eligibility"`
+	IgnoreCourseGroups bool   `json:"ignore_course_groups"`
+}
+
+type ForceCancelRequest struct {
+	StudentID uint   `json:"student_id" binding:"required"`
+	CourseID  uint   `json:"course_id" binding:"required"`
+	Reason    string `json:"reason" binding:"required"`
+}
+
+type LimitRequest struct {
+	MaxCourses int `json:"max_courses"` // 0: no limit
+	MaxCredits int `json:"max_credits"` // 0: no limit
+}
+
+type SpecialCourseLimitRequest struct {
+	MaxSpecialCourses int `json:"max_special_courses"` // 0: no limit
+}
+
+type SpecialCourseRuleRequest struct {
+	StudentIDs []uint   `json:"student_ids"`
+	Cohorts    []string `json:"cohorts"`
+}
+
+type SetStudentGroupRequest struct {
+	StudentIDs []uint `json:"student_ids"` // empty: remove the group
+}
+
+type SetPrerequisitesRequest struct {
+	PrerequisiteIDs []uint `json:"prerequisite_ids"` // empty: remove all prerequisites
+}
+
+type CreateTermRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type ActivateTermRequest struct {
+	TermID uint `json:"term_id" binding:"required"`
+}
+
+type SetRoundStudentsRequest struct {
+	StudentIDs []uint `json:"student_ids"`
+}
+
+type AssignCohortsRequest struct {
+	Assignments map[uint]string `json:"assignments" binding:"required"` // studentID -> cohort name
+}
+
+type AssignRandomCohortsRequest struct {
+	Cohorts []string `json:"cohorts" binding:"required"`
+	Seed    int64    `json:"seed"`
+}
+
+type SetRegistrationModeRequest struct {
+	Mode string `json:"mode" binding:"required"` // "FCFS" or "LOTTERY"
+}
+
+type DrawLotteryRequest struct {
+	Seed           *int64 `json:"seed"` // random if omitted; recorded with the run either way
+	WaitlistLosers bool   `json:"waitlist_losers"`
+}
+
+type PreferenceAssignmentRequest struct {
+	Seed     *int64 `json:"seed"`     // random if omitted in a preview; required to commit
+	Checksum string `json:"checksum"` // from the preview; required to commit
+}
+
+type SubmitPreferenceRequest struct {
+	CourseIDs  []uint `json:"course_ids" binding:"required"` // most wanted first
+	MaxCourses int    `json:"max_courses" binding:"required"`
+}
+
+type SubmitCartRequest struct {
+	AllOrNothing bool `json:"all_or_nothing"` // false: enroll whatever succeeds
+}
+
+type CartCourseResult struct {
+	CourseID uint   `json:"course_id"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+}
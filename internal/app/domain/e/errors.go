@@ -14,7 +14,66 @@ var (
 	ErrStudentNotFound           = errors.New("student not found")
 	ErrTimeConflict              = errors.New("time conflict with enrolled course")
 	ErrAlreadyEnrolled           = errors.New("already enrolled in this course")
+	ErrNotEnrolled               = errors.New("not enrolled in this course")
 	ErrCourseFull                = errors.New("course is full")
+	ErrCourseNotFull             = errors.New("course still has seats available")
+	ErrAlreadyWaitlisted         = errors.New("already on the waitlist of this course")
+	ErrNotWaitlisted             = errors.New("not on the waitlist of this course")
+	ErrWaitlistFull              = errors.New("waitlist is full")
 	ErrEnrollmentDBFailed        = errors.New("failed to save enrollment")
 	ErrInvalidRegistrationPeriod = errors.New("not within registration period")
+	ErrCourseLimitExceeded       = errors.New("maximum number of courses exceeded")
+	ErrCreditLimitExceeded       = errors.New("maximum number of credits exceeded")
+	ErrSpecialLimitExceeded      = errors.New("maximum number of special courses exceeded")
+	ErrNotEligibleForCourse      = errors.New("student is not eligible for this course")
+	ErrPrerequisiteNotMet        = errors.New("prerequisite course not completed")
+	ErrExclusiveCourseConflict   = errors.New("already enrolled in another course of the same exclusive group")
+	ErrCorequisiteFailed         = errors.New("co-requisite course could not be enrolled")
+	ErrCorequisiteUnsupported    = errors.New("operation not available for courses with co-requisites")
+	ErrCourseGroupNotFound       = errors.New("course group not found")
+	ErrEligibilityRuleNotFound   = errors.New("eligibility rule not found")
+
+	// for Enrollment Worker
+	ErrServerBusy       = errors.New("enrollment queue is full")
+	ErrRequestAbandoned = errors.New("request abandoned before the worker replied")
+
+	// for Idempotency Keys
+	ErrIdempotencyKeyReused = errors.New("idempotency key already used for another request")
+
+	// for Seat Holds
+	ErrSeatAlreadyHeld = errors.New("student already holds a seat in this course")
+	ErrNoSeatHold      = errors.New("no seat hold for this course")
+	ErrHoldExpired     = errors.New("seat hold has expired")
+	ErrHoldConflict    = errors.New("course conflicts with another held seat")
+	ErrTooManyHolds    = errors.New("student holds the maximum number of seats")
+
+	// for Cart
+	ErrAlreadyInCart = errors.New("course is already in the cart")
+	ErrNotInCart     = errors.New("course is not in the cart")
+	ErrCartFull      = errors.New("cart is full")
+	ErrCartEmpty     = errors.New("cart is empty")
+	ErrCartRejected  = errors.New("cart rejected because another course in it failed")
+
+	// for Registration Lifecycle
+	ErrAlreadyInPhase           = errors.New("registration is already in the requested phase")
+	ErrIllegalPhaseTransition   = errors.New("illegal registration phase transition")
+	ErrInvalidRegistrationPhase = errors.New("operation not allowed in current registration phase")
+
+	// for Terms
+	ErrTermNotFound     = errors.New("term not found")
+	ErrDuplicateTerm    = errors.New("term name already exists")
+	ErrRolloverProblems = errors.New("term rollover plan has problems")
+
+	// for Registration Rounds
+	ErrRoundNotFound       = errors.New("registration round not found")
+	ErrNotEligibleForRound = errors.New("student is not eligible for the current round")
+	ErrOperationNotAllowed = errors.New("operation not allowed in the current round")
+	ErrEntryNotYetOpen     = errors.New("cohort entry time has not come yet")
+
+	// for Allocation Modes
+	ErrWrongRegistrationMode = errors.New("operation not available in current registration mode")
+	ErrAlreadyApplied        = errors.New("already applied to this course")
+	ErrNotApplied            = errors.New("not applied to this course")
+	ErrNoPreference          = errors.New("no course preference submitted")
+	ErrAssignmentChanged     = errors.New("assignment differs from the preview")
 )
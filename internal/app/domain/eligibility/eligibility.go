@@ -0,0 +1,196 @@
+// Package eligibility decides which students may enroll in which courses, based on student attributes.
+// Rules are built once from their stored definitions, so checks never touch the DB.
+package eligibility
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Rule types
+const (
+	TypeMinAge    = "MIN_AGE"
+	TypeMaxAge    = "MAX_AGE"
+	TypeGroup     = "GROUP"
+	TypeCompleted = "COMPLETED"
+)
+
+// birthDateLayout is the format of models.Student.BirthDate
+const birthDateLayout = "2006-01-02"
+
+// Student is what rules are evaluated against
+type Student struct {
+	ID        uint
+	BirthDate time.Time // zero if unknown
+	Groups    map[string]struct{}
+	Completed map[string]struct{} // codes of courses completed in previous terms
+}
+
+// Rule is one eligibility condition
+type Rule interface {
+	// Check returns "" if the student meets the rule, or the reason they do not
+	Check(s *Student) string
+}
+
+// NotEligibleError is returned when a student does not meet a course's rule, with the reason
+type NotEligibleError struct {
+	CourseID uint
+	Reason   string
+}
+
+func (err *NotEligibleError) Error() string {
+	return fmt.Sprintf("%v (course %d): %s", e.ErrNotEligibleForCourse, err.CourseID, err.Reason)
+}
+
+func (err *NotEligibleError) Unwrap() error {
+	return e.ErrNotEligibleForCourse
+}
+
+// MinAge requires the student to be at least Age full years old on AsOf
+type MinAge struct {
+	Age  int
+	AsOf time.Time
+}
+
+func (r MinAge) Check(s *Student) string {
+	if s.BirthDate.IsZero() {
+		return "birth date is unknown"
+	}
+	if AgeOn(s.BirthDate, r.AsOf) < r.Age {
+		return fmt.Sprintf("age must be ≥ %d", r.Age)
+	}
+	return ""
+}
+
+// MaxAge requires the student to be at most Age full years old on AsOf
+type MaxAge struct {
+	Age  int
+	AsOf time.Time
+}
+
+func (r MaxAge) Check(s *Student) string {
+	if s.BirthDate.IsZero() {
+		return "birth date is unknown"
+	}
+	if AgeOn(s.BirthDate, r.AsOf) > r.Age {
+		return fmt.Sprintf("age must be ≤ %d", r.Age)
+	}
+	return ""
+}
+
+// InGroup requires the student to be a member of any of the groups
+type InGroup struct {
+	Groups []string
+}
+
+func (r InGroup) Check(s *Student) string {
+	for _, group := range r.Groups {
+		if _, ok := s.Groups[group]; ok {
+			return ""
+		}
+	}
+	return fmt.Sprintf("must be a member of %s", strings.Join(r.Groups, " or "))
+}
+
+// Completed requires the student to have completed all of the courses, by code, in a previous term
+type Completed struct {
+	CourseCodes []string
+}
+
+func (r Completed) Check(s *Student) string {
+	for _, code := range r.CourseCodes {
+		if _, ok := s.Completed[code]; !ok {
+			return fmt.Sprintf("must have completed course %s", code)
+		}
+	}
+	return ""
+}
+
+// AgeOn returns the full years between birth and date
+func AgeOn(birth, date time.Time) int {
+	age := date.Year() - birth.Year()
+	if date.Month() < birth.Month() || (date.Month() == birth.Month() && date.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
+// ParseBirthDate parses a stored birth date; the zero time is returned if it is malformed
+func ParseBirthDate(birthDate string) time.Time {
+	t, err := time.Parse(birthDateLayout, birthDate)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// NewRule builds a rule from its stored definition; ages are computed on asOf
+func NewRule(def models.EligibilityRule, asOf time.Time) (Rule, error) {
+	switch def.Type {
+	case TypeMinAge, TypeMaxAge:
+		if def.Age <= 0 {
+			return nil, fmt.Errorf("%w: %s rule needs a positive age", e.ErrInvalidInput, def.Type)
+		}
+		if def.Type == TypeMinAge {
+			return MinAge{Age: def.Age, AsOf: asOf}, nil
+		}
+		return MaxAge{Age: def.Age, AsOf: asOf}, nil
+	case TypeGroup:
+		if len(def.Groups) == 0 {
+			return nil, fmt.Errorf("%w: GROUP rule needs at least one group", e.ErrInvalidInput)
+		}
+		return InGroup{Groups: def.Groups}, nil
+	case TypeCompleted:
+		if len(def.CourseCodes) == 0 {
+			return nil, fmt.Errorf("%w: COMPLETED rule needs at least one course code", e.ErrInvalidInput)
+		}
+		return Completed{CourseCodes: def.CourseCodes}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown rule type %q", e.ErrInvalidInput, def.Type)
+	}
+}
+
+// Engine holds the rules of every course and the attributes of every student
+type Engine struct {
+	rules    map[uint][]Rule // courseID -> rules
+	students map[uint]*Student
+}
+
+// NewEngine builds the rules of every course; students maps studentID to the student's attributes
+func NewEngine(defs []models.EligibilityRule, students map[uint]*Student, asOf time.Time) (*Engine, error) {
+	engine := &Engine{
+		rules:    make(map[uint][]Rule),
+		students: students,
+	}
+
+	for _, def := range defs {
+		rule, err := NewRule(def, asOf)
+		if err != nil {
+			return nil, fmt.Errorf("eligibility rule %d: %w", def.ID, err)
+		}
+		engine.rules[def.CourseID] = append(engine.rules[def.CourseID], rule)
+	}
+	return engine, nil
+}
+
+// Check returns a NotEligibleError for the first rule of the course the student does not meet.
+// Courses without rules are open to every student.
+func (engine *Engine) Check(studentID, courseID uint) error {
+	rules := engine.rules[courseID]
+	if len(rules) == 0 {
+		return nil
+	}
+	s, ok := engine.students[studentID]
+	if !ok {
+		s = &Student{ID: studentID}
+	}
+	for _, rule := range rules {
+		if reason := rule.Check(s); reason != "" {
+			return &NotEligibleError{CourseID: courseID, Reason: reason}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package eligibility
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func TestAgeOn(t *testing.T) {
+	birth := ParseBirthDate("2006-03-15")
+	tests := []struct {
+		date string
+		want int
+	}{
+		{"2025-03-14", 18},
+		{"2025-03-15", 19},
+		{"2025-12-31", 19},
+	}
+	for _, tt := range tests {
+		date, _ := time.Parse(birthDateLayout, tt.date)
+		if got := AgeOn(birth, date); got != tt.want {
+			t.Errorf("AgeOn(%s): got %d, want %d", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestEngineCheck(t *testing.T) {
+	asOf := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	students := map[uint]*Student{
+		1: {ID: 1, BirthDate: ParseBirthDate("2000-01-01"), Groups: map[string]struct{}{"athletes": {}}, Completed: map[string]struct{}{"CS101": {}}},
+		2: {ID: 2, BirthDate: ParseBirthDate("2010-01-01")},
+		3: {ID: 3, BirthDate: ParseBirthDate("not a date")},
+	}
+	engine, err := NewEngine([]models.EligibilityRule{
+		{ID: 1, CourseID: 10, Type: TypeMinAge, Age: 19},
+		{ID: 2, CourseID: 10, Type: TypeGroup, Groups: []string{"athletes", "coaches"}},
+		{ID: 3, CourseID: 20, Type: TypeMaxAge, Age: 18},
+		{ID: 4, CourseID: 30, Type: TypeCompleted, CourseCodes: []string{"CS101"}},
+	}, students, asOf)
+	if err != nil {
+		t.Fatalf("new engine: %v", err)
+	}
+
+	tests := []struct {
+		studentID, courseID uint
+		reason              string // "" if eligible
+	}{
+		{1, 10, ""},
+		{2, 10, "age must be ≥ 19"},
+		{3, 10, "birth date is unknown"},
+		{1, 20, "age must be ≤ 18"},
+		{2, 20, ""},
+		{1, 30, ""},
+		{2, 30, "must have completed course CS101"},
+		{2, 40, ""}, // no rules
+		{99, 40, ""},
+	}
+	for _, tt := range tests {
+		err := engine.Check(tt.studentID, tt.courseID)
+		if tt.reason == "" {
+			if err != nil {
+				t.Errorf("student %d, course %d: unexpected error %v", tt.studentID, tt.courseID, err)
+			}
+			continue
+		}
+		var notEligible *NotEligibleError
+		if !errors.As(err, &notEligible) || !errors.Is(err, e.ErrNotEligibleForCourse) {
+			t.Errorf("student %d, course %d: got %v, want NotEligibleError", tt.studentID, tt.courseID, err)
+			continue
+		}
+		if notEligible.Reason != tt.reason {
+			t.Errorf("student %d, course %d: reason %q, want %q", tt.studentID, tt.courseID, notEligible.Reason, tt.reason)
+		}
+	}
+
+	// Group rule on a student without groups
+	students[2].Groups = map[string]struct{}{}
+	students[2].BirthDate = ParseBirthDate("2000-01-01")
+	var notEligible *NotEligibleError
+	if err := engine.Check(2, 10); !errors.As(err, &notEligible) || notEligible.Reason != "must be a member of athletes or coaches" {
+		t.Errorf("group rule: got %v", err)
+	}
+}
+
+func TestNewRuleValidation(t *testing.T) {
+	invalid := []models.EligibilityRule{
+		{Type: "UNKNOWN"},
+		{Type: TypeMinAge},
+		{Type: TypeGroup},
+		{Type: TypeCompleted},
+	}
+	for _, def := range invalid {
+		if _, err := NewRule(def, time.Now()); !errors.Is(err, e.ErrInvalidInput) {
+			t.Errorf("%+v: got %v, want %v", def, err, e.ErrInvalidInput)
+		}
+	}
+}
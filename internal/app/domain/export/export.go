@@ -11,12 +11,13 @@ const StaticCoursesFilePath = "static/courses.json"
 
 var exportMu sync.Mutex
 
-func ExportCoursesToJson(courseRepo repository.CourseRepositoryInterface) error {
+// ExportCoursesToJson writes the courses of the given (active) term to the static courses file
+func ExportCoursesToJson(courseRepo repository.CourseRepositoryInterface, termID uint) error {
 	filePath := StaticCoursesFilePath
 	exportMu.Lock()
 	defer exportMu.Unlock()
 
-	courses, err := courseRepo.FetchAllCourses()
+	courses, err := courseRepo.FetchAllCourses(termID)
 	if err != nil {
 		log.Println("[error] fetch all courses failed:", err.Error())
 		return err
@@ -0,0 +1,157 @@
+package idempotency
+
+import (
+	"context"
+	"course-reg/internal/app/domain/e"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// MaxKeyLength is the longest Idempotency-Key accepted
+const MaxKeyLength = 255
+
+// MaxKeysPerStudent is how many keys are remembered per student; using another one forgets the oldest
+const MaxKeysPerStudent = 64
+
+// Store remembers the outcome of requests per student and Idempotency-Key for one registration window,
+// so a retried or double-submitted request gets the original result instead of running again.
+type Store struct {
+	mu       sync.Mutex
+	students map[uint]*studentKeys
+}
+
+type studentKeys struct {
+	entries map[string]*entry
+	order   []string // keys from oldest to newest
+}
+
+type entry struct {
+	request string        // what the key was first used for
+	done    chan struct{} // closed once err is set
+	err     error
+}
+
+func NewStore() *Store {
+	return &Store{students: make(map[uint]*studentKeys)}
+}
+
+// Submit enqueues a request and returns wait, which blocks until the request's outcome is known
+type Submit func(ctx context.Context) (wait func() error, err error)
+
+// Do submits a request once per student and key, and returns its outcome to every later request with the same key.
+// Callers wait for the outcome up to ctx; the request is submitted with a ctx that is never cancelled,
+// so a caller giving up leaves the key pending until the outcome arrives instead of running the request again.
+// request describes what the key is used for (e.g. "enroll:10"); reusing a key for another request is rejected.
+// Outcomes that say nothing about the request itself are not remembered, so retrying them submits again.
+// An empty key submits with ctx and remembers nothing.
+func (s *Store) Do(ctx context.Context, studentID uint, key, request string, submit Submit) error {
+	if key == "" {
+		wait, err := submit(ctx)
+		if err != nil {
+			return err
+		}
+		ent := &entry{done: make(chan struct{})}
+		go func() {
+			ent.err = wait()
+			close(ent.done)
+		}()
+		return ent.await(ctx)
+	}
+	if len(key) > MaxKeyLength {
+		return fmt.Errorf("%w: idempotency key longer than %d characters", e.ErrInvalidInput, MaxKeyLength)
+	}
+
+	s.mu.Lock()
+	ent, exists := s.lookup(studentID, key)
+	if !exists {
+		ent = &entry{request: request, done: make(chan struct{})}
+		s.remember(studentID, key, ent)
+	}
+	s.mu.Unlock()
+
+	if exists {
+		if ent.request != request {
+			return fmt.Errorf("%w (used for %s, got %s)", e.ErrIdempotencyKeyReused, ent.request, request)
+		}
+		return ent.await(ctx)
+	}
+
+	wait, err := submit(context.WithoutCancel(ctx))
+	if err != nil {
+		s.finish(studentID, key, ent, err)
+		return err
+	}
+	go func() { s.finish(studentID, key, ent, wait()) }()
+	return ent.await(ctx)
+}
+
+// Reset forgets every outcome, when the registration window ends
+func (s *Store) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.students = make(map[uint]*studentKeys)
+}
+
+// await returns the entry's outcome, or ErrRequestAbandoned once ctx is done
+func (ent *entry) await(ctx context.Context) error {
+	select {
+	case <-ent.done:
+		return ent.err
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", e.ErrRequestAbandoned, ctx.Err())
+	}
+}
+
+// finish records the outcome of ent, forgetting its key if the outcome is not final
+func (s *Store) finish(studentID uint, key string, ent *entry, err error) {
+	ent.err = err
+	if !isFinal(err) {
+		s.mu.Lock()
+		s.forget(studentID, key, ent)
+		s.mu.Unlock()
+	}
+	close(ent.done)
+}
+
+// lookup, remember and forget must be called with mu held
+func (s *Store) lookup(studentID uint, key string) (*entry, bool) {
+	keys, ok := s.students[studentID]
+	if !ok {
+		return nil, false
+	}
+	ent, ok := keys.entries[key]
+	return ent, ok
+}
+
+func (s *Store) remember(studentID uint, key string, ent *entry) {
+	keys, ok := s.students[studentID]
+	if !ok {
+		keys = &studentKeys{entries: make(map[string]*entry)}
+		s.students[studentID] = keys
+	}
+	if len(keys.order) >= MaxKeysPerStudent {
+		delete(keys.entries, keys.order[0])
+		keys.order = keys.order[1:]
+	}
+	keys.entries[key] = ent
+	keys.order = append(keys.order, key)
+}
+
+// forget removes key only if it still belongs to ent, since it may have been evicted and reused since
+func (s *Store) forget(studentID uint, key string, ent *entry) {
+	keys, ok := s.students[studentID]
+	if !ok || keys.entries[key] != ent {
+		return
+	}
+	delete(keys.entries, key)
+	keys.order = slices.DeleteFunc(keys.order, func(k string) bool { return k == key })
+}
+
+// isFinal reports whether err is the result of the request, rather than a reason it was not processed:
+// the queue was full or the row could not be saved
+func isFinal(err error) bool {
+	return !errors.Is(err, e.ErrServerBusy) &&
+		!errors.Is(err, e.ErrEnrollmentDBFailed)
+}
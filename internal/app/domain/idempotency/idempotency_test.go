@@ -0,0 +1,167 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"course-reg/internal/app/domain/e"
+)
+
+// counter submits requests with a fixed outcome and counts how often it was called.
+// submitErr fails the submission itself, like a full queue.
+type counter struct {
+	calls     int
+	err       error
+	submitErr error
+}
+
+func (c *counter) fn(context.Context) (func() error, error) {
+	c.calls++
+	if c.submitErr != nil {
+		return nil, c.submitErr
+	}
+	err := c.err
+	return func() error { return err }, nil
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("outcome is replayed", func(t *testing.T) {
+		s := NewStore()
+		for i, want := range []error{nil, e.ErrCourseFull} {
+			c := &counter{err: want}
+			key := fmt.Sprintf("key-%d", i)
+			for range 3 {
+				if err := s.Do(ctx, 1, key, "enroll:10", c.fn); !errors.Is(err, want) {
+					t.Errorf("got %v, want %v", err, want)
+				}
+			}
+			if c.calls != 1 {
+				t.Errorf("fn ran %d times, want 1", c.calls)
+			}
+		}
+	})
+
+	t.Run("keys are per student and request", func(t *testing.T) {
+		s := NewStore()
+		c := &counter{}
+		s.Do(ctx, 1, "key", "enroll:10", c.fn)
+		if err := s.Do(ctx, 2, "key", "enroll:10", c.fn); err != nil || c.calls != 2 {
+			t.Errorf("another student's key should run again, got %v after %d calls", err, c.calls)
+		}
+		if err := s.Do(ctx, 1, "key", "cancel:10", c.fn); !errors.Is(err, e.ErrIdempotencyKeyReused) || c.calls != 2 {
+			t.Errorf("reused key: got %v after %d calls, want %v", err, c.calls, e.ErrIdempotencyKeyReused)
+		}
+	})
+
+	t.Run("unprocessed outcomes are not remembered", func(t *testing.T) {
+		s := NewStore()
+		for i, c := range []*counter{{submitErr: e.ErrServerBusy}, {err: e.ErrEnrollmentDBFailed}} {
+			key := fmt.Sprintf("key-%d", i)
+			s.Do(ctx, 1, key, "enroll:10", c.fn)
+			c.err, c.submitErr = nil, nil
+			if err := s.Do(ctx, 1, key, "enroll:10", c.fn); err != nil || c.calls != 2 {
+				t.Errorf("retry %d: got %v after %d calls", i, err, c.calls)
+			}
+		}
+	})
+
+	t.Run("empty key and reset", func(t *testing.T) {
+		s := NewStore()
+		c := &counter{}
+		s.Do(ctx, 1, "", "enroll:10", c.fn)
+		s.Do(ctx, 1, "", "enroll:10", c.fn)
+		s.Do(ctx, 1, "key", "enroll:10", c.fn)
+		s.Reset()
+		s.Do(ctx, 1, "key", "enroll:10", c.fn)
+		if c.calls != 4 {
+			t.Errorf("fn ran %d times, want 4", c.calls)
+		}
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		s := NewStore()
+		c := &counter{}
+		if err := s.Do(ctx, 1, strings.Repeat("k", MaxKeyLength+1), "enroll:10", c.fn); !errors.Is(err, e.ErrInvalidInput) || c.calls != 0 {
+			t.Errorf("got %v after %d calls, want %v", err, c.calls, e.ErrInvalidInput)
+		}
+	})
+}
+
+func TestDoWaitsForRunningRequest(t *testing.T) {
+	s := NewStore()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	calls := 0
+	slow := func(context.Context) (func() error, error) {
+		calls++
+		close(entered)
+		return func() error {
+			<-release
+			return e.ErrTimeConflict
+		}, nil
+	}
+
+	first := make(chan error, 1)
+	go func() { first <- s.Do(context.Background(), 1, "key", "enroll:10", slow) }()
+	<-entered
+
+	// A duplicate whose client gives up stops waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Do(ctx, 1, "key", "enroll:10", slow); !errors.Is(err, e.ErrRequestAbandoned) {
+		t.Errorf("cancelled duplicate: got %v, want %v", err, e.ErrRequestAbandoned)
+	}
+
+	second := make(chan error, 1)
+	go func() { second <- s.Do(context.Background(), 1, "key", "enroll:10", slow) }()
+	close(release)
+
+	for name, ch := range map[string]chan error{"first": first, "second": second} {
+		if err := <-ch; !errors.Is(err, e.ErrTimeConflict) {
+			t.Errorf("%s: got %v, want %v", name, err, e.ErrTimeConflict)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fn ran %d times, want 1", calls)
+	}
+}
+
+func TestDoKeepsOutcomeOfAbandonedRequest(t *testing.T) {
+	s := NewStore()
+	release := make(chan struct{})
+	calls := 0
+	var submitted context.Context
+	slow := func(ctx context.Context) (func() error, error) {
+		calls++
+		submitted = ctx
+		return func() error {
+			<-release
+			return e.ErrCourseFull
+		}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Do(ctx, 1, "key", "enroll:10", slow); !errors.Is(err, e.ErrRequestAbandoned) {
+		t.Fatalf("abandoned request: got %v, want %v", err, e.ErrRequestAbandoned)
+	}
+	if submitted.Err() != nil {
+		t.Errorf("request was submitted with a cancelled context")
+	}
+
+	// The retry waits for the outcome of the request still in flight
+	retry := make(chan error, 1)
+	go func() { retry <- s.Do(context.Background(), 1, "key", "enroll:10", slow) }()
+	close(release)
+	if err := <-retry; !errors.Is(err, e.ErrCourseFull) {
+		t.Errorf("retry: got %v, want %v", err, e.ErrCourseFull)
+	}
+	if calls != 1 {
+		t.Errorf("fn ran %d times, want 1", calls)
+	}
+}
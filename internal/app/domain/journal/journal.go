@@ -0,0 +1,208 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"course-reg/internal/app/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Journal is an append-only local file of enrollment rows, written and fsynced before the worker acknowledges them.
+// Rows are applied to the DB later by Flush, so a DB outage does not fail enrollments.
+// Each line is a JSON record: a batch of rows, or a marker saying every batch up to a sequence number is applied.
+type Journal struct {
+	mu      sync.Mutex // guards file, size, nextSeq and pending
+	flushMu sync.Mutex // serializes Flush, so batches are applied once and in order
+	file    *os.File
+	size    int64 // bytes of complete records in file
+	nextSeq uint64
+	pending []record // appended but not applied yet, in order
+}
+
+type record struct {
+	Seq     uint64              `json:"seq,omitempty"`
+	Rows    []models.Enrollment `json:"rows,omitempty"`
+	Flushed uint64              `json:"flushed,omitempty"` // set only on markers
+}
+
+// Open opens the journal at path, creating it if needed, and loads the batches not applied yet.
+// A torn last line left by a crash during Append is dropped; it was never acknowledged.
+func Open(path string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create journal directory failed: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open journal failed: %w", err)
+	}
+
+	j := &Journal{file: file, nextSeq: 1}
+	validSize, err := j.load()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Truncate(validSize); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("truncate journal failed: %w", err)
+	}
+	if _, err := file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("seek journal failed: %w", err)
+	}
+	j.size = validSize
+	return j, nil
+}
+
+// load reads every record and returns the size of the file up to the last complete one
+func (j *Journal) load() (int64, error) {
+	reader := bufio.NewReader(j.file)
+	var size int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return size, nil // a line without a newline was torn by a crash
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read journal failed: %w", err)
+		}
+
+		var rec record
+		if err := json.Unmarshal(bytes.TrimSpace(line), &rec); err != nil {
+			return 0, fmt.Errorf("corrupt journal record at byte %d: %w", size, err)
+		}
+		size += int64(len(line))
+
+		if rec.Flushed > 0 {
+			j.dropApplied(rec.Flushed)
+			continue
+		}
+		j.pending = append(j.pending, rec)
+		j.nextSeq = rec.Seq + 1
+	}
+}
+
+// Append durably records rows as one batch
+func (j *Journal) Append(rows []models.Enrollment) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	rec := record{Seq: j.nextSeq, Rows: rows}
+	if err := j.write(rec); err != nil {
+		return err
+	}
+	j.nextSeq++
+	j.pending = append(j.pending, rec)
+	return nil
+}
+
+// Pending returns the rows not applied to the DB yet, in the order they were appended
+func (j *Journal) Pending() []models.Enrollment {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var rows []models.Enrollment
+	for _, rec := range j.pending {
+		rows = append(rows, rec.Rows...)
+	}
+	return rows
+}
+
+// Flush applies the pending rows with apply and records that they are applied.
+// apply must tolerate rows it already applied, since a crash can happen before the record is written.
+// The file is emptied once nothing is pending.
+func (j *Journal) Flush(apply func(rows []models.Enrollment) error) error {
+	j.flushMu.Lock()
+	defer j.flushMu.Unlock()
+
+	j.mu.Lock()
+	batch := append([]record(nil), j.pending...)
+	j.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var rows []models.Enrollment
+	for _, rec := range batch {
+		rows = append(rows, rec.Rows...)
+	}
+	if err := apply(rows); err != nil {
+		return err
+	}
+
+	// The batches stay pending until the file says they are applied; otherwise a crash after a failed
+	// write would replay them over changes made after this flush
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	last := batch[len(batch)-1].Seq
+	var err error
+	if j.pending[len(j.pending)-1].Seq == last {
+		err = j.truncate()
+	} else {
+		err = j.write(record{Flushed: last})
+	}
+	if err != nil {
+		return err
+	}
+	j.dropApplied(last)
+	return nil
+}
+
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// dropApplied removes the pending batches up to seq
+func (j *Journal) dropApplied(seq uint64) {
+	i := 0
+	for i < len(j.pending) && j.pending[i].Seq <= seq {
+		i++
+	}
+	j.pending = j.pending[i:]
+}
+
+// write appends one record and fsyncs it. A failed write is cut off again, so the next record starts on a clean line.
+func (j *Journal) write(rec record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode journal record failed: %w", err)
+	}
+	line = append(line, '\n')
+
+	if _, err = j.file.Write(line); err != nil {
+		err = fmt.Errorf("write journal failed: %w", err)
+	} else if err = j.file.Sync(); err != nil {
+		err = fmt.Errorf("sync journal failed: %w", err)
+	}
+	if err != nil {
+		if truncErr := j.file.Truncate(j.size); truncErr == nil {
+			j.file.Seek(j.size, io.SeekStart)
+		}
+		return err
+	}
+	j.size += int64(len(line))
+	return nil
+}
+
+// truncate empties the file once every batch is applied
+func (j *Journal) truncate() error {
+	if err := j.file.Truncate(0); err != nil {
+		return fmt.Errorf("truncate journal failed: %w", err)
+	}
+	if _, err := j.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek journal failed: %w", err)
+	}
+	j.size = 0
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("sync journal failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"course-reg/internal/app/models"
+)
+
+func row(studentID, courseID uint) models.Enrollment {
+	return models.Enrollment{TermID: 1, StudentID: studentID, CourseID: courseID}
+}
+
+func studentIDs(rows []models.Enrollment) []uint {
+	ids := make([]uint, len(rows))
+	for i, r := range rows {
+		ids[i] = r.StudentID
+	}
+	return ids
+}
+
+func equalIDs(got, want []uint) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func mustOpen(t *testing.T, path string) *Journal {
+	t.Helper()
+	j, err := Open(path)
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	t.Cleanup(func() { j.Close() })
+	return j
+}
+
+func TestReopenKeepsPendingRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "enrollments.journal")
+	j := mustOpen(t, path)
+	if err := j.Append([]models.Enrollment{row(1, 10), row(2, 10)}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := j.Append([]models.Enrollment{row(3, 20)}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	j.Close()
+
+	j = mustOpen(t, path)
+	if got := studentIDs(j.Pending()); !equalIDs(got, []uint{1, 2, 3}) {
+		t.Errorf("pending after reopen: got %v, want [1 2 3]", got)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "enrollments.journal")
+	j := mustOpen(t, path)
+	if err := j.Append([]models.Enrollment{row(1, 10)}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	if err := j.Flush(func([]models.Enrollment) error { return errors.New("db down") }); err == nil {
+		t.Fatalf("flush should return the apply error")
+	}
+	if got := studentIDs(j.Pending()); !equalIDs(got, []uint{1}) {
+		t.Errorf("failed flush should keep rows: got %v", got)
+	}
+
+	// A batch appended while applying stays pending; a marker records the applied one
+	var applied []uint
+	err := j.Flush(func(rows []models.Enrollment) error {
+		applied = append(applied, studentIDs(rows)...)
+		return j.Append([]models.Enrollment{row(2, 10)})
+	})
+	if err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if !equalIDs(applied, []uint{1}) {
+		t.Errorf("applied: got %v, want [1]", applied)
+	}
+	j.Close()
+
+	j = mustOpen(t, path)
+	if got := studentIDs(j.Pending()); !equalIDs(got, []uint{2}) {
+		t.Errorf("pending after reopen: got %v, want [2]", got)
+	}
+
+	// Once everything is applied the file is emptied
+	if err := j.Flush(func([]models.Enrollment) error { return nil }); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() != 0 {
+		t.Errorf("journal should be empty after a full flush (err: %v)", err)
+	}
+}
+
+func TestFailedMarkerKeepsRows(t *testing.T) {
+	j := mustOpen(t, filepath.Join(t.TempDir(), "enrollments.journal"))
+	if err := j.Append([]models.Enrollment{row(1, 10)}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	// Appending while applying makes Flush write a marker, which fails on the closed file
+	err := j.Flush(func([]models.Enrollment) error {
+		if err := j.Append([]models.Enrollment{row(2, 10)}); err != nil {
+			return err
+		}
+		return j.file.Close()
+	})
+	if err == nil {
+		t.Fatalf("flush should return the marker write error")
+	}
+	if got := studentIDs(j.Pending()); !equalIDs(got, []uint{1, 2}) {
+		t.Errorf("rows without a marker should stay pending: got %v, want [1 2]", got)
+	}
+}
+
+func TestTornRecordIsDropped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "enrollments.journal")
+	j := mustOpen(t, path)
+	if err := j.Append([]models.Enrollment{row(1, 10)}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	j.Close()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open file: %v", err)
+	}
+	f.WriteString(`{"seq":2,"rows":[{"StudentID":`)
+	f.Close()
+
+	j = mustOpen(t, path)
+	if err := j.Append([]models.Enrollment{row(3, 10)}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	j.Close()
+
+	j = mustOpen(t, path)
+	if got := studentIDs(j.Pending()); !equalIDs(got, []uint{1, 3}) {
+		t.Errorf("pending: got %v, want [1 3]", got)
+	}
+}
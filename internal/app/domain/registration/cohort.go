@@ -0,0 +1,65 @@
+package registration
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/pkg/utils"
+	"fmt"
+	"time"
+)
+
+// EntryTimeError is returned when a student tries to enroll before their cohort's entry time
+type EntryTimeError struct {
+	EntryTime time.Time
+}
+
+func (err *EntryTimeError) Error() string {
+	return fmt.Sprintf("%v: entry opens at %s", e.ErrEntryNotYetOpen, err.EntryTime.Format(time.RFC3339))
+}
+
+func (err *EntryTimeError) Unwrap() error {
+	return e.ErrEntryNotYetOpen
+}
+
+// SetEntryOffsets replaces the per-student entry offsets (studentID -> offset from window start)
+func (rs *State) SetEntryOffsets(offsets map[uint]time.Duration) {
+	rs.ruleMu.Lock()
+	defer rs.ruleMu.Unlock()
+	rs.entryOffsets = offsets
+}
+
+// CheckEntryTime checks if the student's cohort has entered at now.
+// Offsets count from the active round start, or from the registration period start when no rounds are set.
+// Without either, entry is not staggered.
+func (rs *State) CheckEntryTime(now time.Time, studentID uint) error {
+	rs.ruleMu.RLock()
+	defer rs.ruleMu.RUnlock()
+
+	offset := rs.entryOffsets[studentID]
+	if offset <= 0 {
+		return nil
+	}
+
+	var windowStart time.Time
+	if len(rs.rounds) > 0 {
+		round, ok := rs.activeRound(now)
+		if !ok {
+			return nil // CheckRoundAccess reports this
+		}
+		windowStart = round.Start
+	} else {
+		if rs.startTime == "" {
+			return nil
+		}
+		start, err := utils.StringToTime(rs.startTime)
+		if err != nil {
+			return nil
+		}
+		windowStart = start
+	}
+
+	entryTime := windowStart.Add(offset)
+	if now.Before(entryTime) {
+		return &EntryTimeError{EntryTime: entryTime}
+	}
+	return nil
+}
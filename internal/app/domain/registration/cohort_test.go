@@ -0,0 +1,39 @@
+package registration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"course-reg/internal/app/domain/e"
+)
+
+func TestCheckEntryTime(t *testing.T) {
+	state := NewState(PhaseOpen, "2025-01-20-09-00", "2025-01-25-18-00")
+	state.SetEntryOffsets(map[uint]time.Duration{2: 10 * time.Minute})
+	start := mustTime(t, "2025-01-20-09-00")
+
+	if err := state.CheckEntryTime(start, 1); err != nil {
+		t.Errorf("student without offset: unexpected error %v", err)
+	}
+
+	err := state.CheckEntryTime(start.Add(5*time.Minute), 2)
+	var entryErr *EntryTimeError
+	if !errors.As(err, &entryErr) || !errors.Is(err, e.ErrEntryNotYetOpen) {
+		t.Fatalf("got %v, want EntryTimeError", err)
+	}
+	if !entryErr.EntryTime.Equal(start.Add(10 * time.Minute)) {
+		t.Errorf("entry time: got %v, want %v", entryErr.EntryTime, start.Add(10*time.Minute))
+	}
+
+	if err := state.CheckEntryTime(start.Add(10*time.Minute), 2); err != nil {
+		t.Errorf("at entry time: unexpected error %v", err)
+	}
+
+	// With rounds, offsets count from the active round start
+	roundStart := mustTime(t, "2025-01-22-09-00")
+	state.SetRounds([]Round{NewRound(1, "general", roundStart, roundStart.Add(time.Hour), OpsEnrollCancel, true, nil)})
+	if err := state.CheckEntryTime(roundStart.Add(time.Minute), 2); !errors.Is(err, e.ErrEntryNotYetOpen) {
+		t.Errorf("round start: got %v, want %v", err, e.ErrEntryNotYetOpen)
+	}
+}
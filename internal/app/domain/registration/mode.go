@@ -0,0 +1,65 @@
+package registration
+
+import (
+	"course-reg/internal/app/domain/e"
+	"fmt"
+)
+
+// Mode is how seats are allocated
+type Mode string
+
+const (
+	ModeFCFS       Mode = "FCFS"       // 선착순 (worker)
+	ModeLottery    Mode = "LOTTERY"    // 신청 기간 후 추첨
+	ModePreference Mode = "PREFERENCE" // 희망 순위 제출 후 일괄 배정
+)
+
+// IsValid checks if the mode is one of the known modes
+func (m Mode) IsValid() bool {
+	switch m {
+	case ModeFCFS, ModeLottery, ModePreference:
+		return true
+	default:
+		return false
+	}
+}
+
+// Mode returns the current allocation mode
+func (rs *State) Mode() Mode {
+	rs.ruleMu.RLock()
+	defer rs.ruleMu.RUnlock()
+	return rs.mode
+}
+
+// SetMode sets the allocation mode without checks (used when loading the stored config)
+func (rs *State) SetMode(mode Mode) {
+	rs.ruleMu.Lock()
+	defer rs.ruleMu.Unlock()
+	rs.mode = mode
+}
+
+// ChangeModeAndAct changes the allocation mode while no one can register (SETUP or CLOSED).
+// CLOSED allows e.g. switching to FCFS for an add/drop period after a lottery draw.
+func (rs *State) ChangeModeAndAct(mode Mode, act func() error) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.phase != PhaseSetup && rs.phase != PhaseClosed {
+		return fmt.Errorf("registration mode cannot change in phase %s: %w", rs.phase, e.ErrInvalidRegistrationPhase)
+	}
+
+	if err := act(); err != nil {
+		return err
+	}
+
+	rs.SetMode(mode)
+	return nil
+}
+
+// RequireMode checks if the current allocation mode is mode
+func (rs *State) RequireMode(mode Mode) error {
+	if current := rs.Mode(); current != mode {
+		return fmt.Errorf("registration mode is %s, expected %s: %w", current, mode, e.ErrWrongRegistrationMode)
+	}
+	return nil
+}
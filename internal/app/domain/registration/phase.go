@@ -0,0 +1,37 @@
+package registration
+
+// Phase is a stage of the registration lifecycle
+type Phase string
+
+const (
+	PhaseSetup     Phase = "SETUP"     // 강의/학생 데이터 준비 중
+	PhaseOpen      Phase = "OPEN"      // 수강 신청 진행 중
+	PhasePaused    Phase = "PAUSED"    // 장애 대응 등으로 일시 중지
+	PhaseClosed    Phase = "CLOSED"    // 수강 신청 종료 (재개 가능)
+	PhaseFinalized Phase = "FINALIZED" // 결과 확정, 더 이상 변경 불가
+)
+
+// transitions lists the legal next phases of each phase
+var transitions = map[Phase][]Phase{
+	PhaseSetup:     {PhaseOpen},
+	PhaseOpen:      {PhasePaused, PhaseClosed},
+	PhasePaused:    {PhaseOpen, PhaseClosed},
+	PhaseClosed:    {PhaseOpen, PhaseFinalized},
+	PhaseFinalized: {PhaseSetup},
+}
+
+// IsValid checks if the phase is one of the known phases
+func (p Phase) IsValid() bool {
+	_, exists := transitions[p]
+	return exists
+}
+
+// CanTransitionTo checks if moving from p to next is a legal transition
+func (p Phase) CanTransitionTo(next Phase) bool {
+	for _, allowed := range transitions[p] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
@@ -2,96 +2,125 @@ package registration
 
 import (
 	"course-reg/internal/app/domain/e"
+	"course-reg/internal/pkg/utils"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 )
 
 type State struct {
-	mu        sync.RWMutex
-	enabled   bool
+	mu    sync.RWMutex // guards phase
+	phase Phase
+
+	ruleMu    sync.RWMutex // guards everything below; never held while acquiring mu
+	term      uint         // active term; only changes together with the phase
+	mode      Mode
 	startTime string
 	endTime   string
+	rounds    []Round
+
+	entryOffsets map[uint]time.Duration // studentID -> cohort entry offset
 }
 
-func NewState(enabled bool, startTime, endTime string) *State {
+func NewState(phase Phase, startTime, endTime string) *State {
 	return &State{
-		enabled:   enabled,
+		phase:     phase,
+		mode:      ModeFCFS,
 		startTime: startTime,
 		endTime:   endTime,
 	}
 }
 
-func (rs *State) IsEnabled() bool {
+// Phase returns the current registration phase
+func (rs *State) Phase() Phase {
 	rs.mu.RLock()
 	defer rs.mu.RUnlock()
-	return rs.enabled
+	return rs.phase
 }
 
-func (rs *State) ChangeEnabledAndAct(enabled bool, act func() error) error {
+// IsOpen checks if students can currently register
+func (rs *State) IsOpen() bool {
+	return rs.Phase() == PhaseOpen
+}
+
+// TransitionAndAct moves to the next phase if the transition is legal and act succeeds.
+// act receives the phase being left.
+func (rs *State) TransitionAndAct(next Phase, act func(prev Phase) error) error {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 
-	if rs.enabled == enabled {
-		// todo : 커스텀 예외로 다른 에러와 구분 될 수 있게 만들기
-		return fmt.Errorf("enabled aleardy set %v", enabled)
+	if rs.phase == next {
+		return fmt.Errorf("registration phase is already %s: %w", next, e.ErrAlreadyInPhase)
+	}
+	if !rs.phase.CanTransitionTo(next) {
+		return fmt.Errorf("registration phase cannot change from %s to %s: %w", rs.phase, next, e.ErrIllegalPhaseTransition)
 	}
 
-	if err := act(); err != nil {
+	if err := act(rs.phase); err != nil {
 		return err
 	}
 
-	rs.enabled = enabled
+	rs.phase = next
 	return nil
 }
 
-func (rs *State) RunIfEnabled(enabled bool, act func() error) error {
+// RunInPhase runs act only if the current phase is one of phases.
+// The phase cannot change while act is running.
+func (rs *State) RunInPhase(act func() error, phases ...Phase) error {
+	return rs.RunInPhaseWith(func(Phase) error { return act() }, phases...)
+}
+
+// RunInPhaseWith is RunInPhase for acts that depend on the current phase.
+// act must not call methods that read the phase (e.g. Phase, IsOpen); use the phase it receives.
+func (rs *State) RunInPhaseWith(act func(phase Phase) error, phases ...Phase) error {
 	if rs.mu.TryRLock() {
 		defer rs.mu.RUnlock()
-		if rs.enabled != enabled {
-			return fmt.Errorf("registration enabled is %v, expected %v: %w", rs.enabled, enabled, e.ErrInvalidRegistrationPeriod)
+		if !slices.Contains(phases, rs.phase) {
+			return fmt.Errorf("registration phase is %s, expected one of %v: %w", rs.phase, phases, e.ErrInvalidRegistrationPhase)
 		}
-		if err := act(); err != nil {
+		if err := act(rs.phase); err != nil {
 			return err
 		}
 	} else {
-		return fmt.Errorf("registration state is being modified: %w", e.ErrInvalidRegistrationPeriod)
+		return fmt.Errorf("registration state is being modified: %w", e.ErrInvalidRegistrationPhase)
 	}
 	return nil
 }
 
 // GetPeriod returns the registration start and end times
 func (rs *State) GetPeriod() (startTime, endTime string) {
-	rs.mu.RLock()
-	defer rs.mu.RUnlock()
+	rs.ruleMu.RLock()
+	defer rs.ruleMu.RUnlock()
 	return rs.startTime, rs.endTime
 }
 
 // SetPeriod sets the registration period
 func (rs *State) SetPeriod(startTime, endTime string) {
-	rs.mu.Lock()
-	defer rs.mu.Unlock()
+	rs.ruleMu.Lock()
+	defer rs.ruleMu.Unlock()
 	rs.startTime = startTime
 	rs.endTime = endTime
 }
 
 // IsWithinRegistrationPeriod checks if the given time is within the registration period
-// func (rs *RegistrationState) IsWithinRegistrationPeriod(now time.Time) (bool, error) {
-// 	rs.mu.RLock()
-// 	defer rs.mu.RUnlock()
-
-// 	if rs.startTime == "" || rs.endTime == "" {
-// 		return false, nil
-// 	}
-
-// 	startTime, err := utils.StringToTime(rs.startTime)
-// 	if err != nil {
-// 		return false, err
-// 	}
-
-// 	endTime, err := utils.StringToTime(rs.endTime)
-// 	if err != nil {
-// 		return false, err
-// 	}
-
-// 	return now.After(startTime) && now.Before(endTime), nil
-// }
+func (rs *State) IsWithinRegistrationPeriod(now time.Time) (bool, error) {
+	rs.ruleMu.RLock()
+	defer rs.ruleMu.RUnlock()
+
+	if rs.startTime == "" || rs.endTime == "" {
+		return false, nil
+	}
+
+	startTime, err := utils.StringToTime(rs.startTime)
+	if err != nil {
+		return false, err
+	}
+
+	endTime, err := utils.StringToTime(rs.endTime)
+	if err != nil {
+		return false, err
+	}
+
+	return !now.Before(startTime) && now.Before(endTime), nil
+}
@@ -0,0 +1,121 @@
+package registration
+
+import (
+	"errors"
+	"testing"
+
+	"course-reg/internal/app/domain/e"
+)
+
+func TestTransitionAndAct(t *testing.T) {
+	noop := func(Phase) error { return nil }
+
+	t.Run("legal transitions", func(t *testing.T) {
+		state := NewState(PhaseSetup, "", "")
+		for _, next := range []Phase{PhaseOpen, PhasePaused, PhaseOpen, PhaseClosed, PhaseFinalized, PhaseSetup} {
+			if err := state.TransitionAndAct(next, noop); err != nil {
+				t.Fatalf("transition to %s: %v", next, err)
+			}
+			if state.Phase() != next {
+				t.Fatalf("phase: got %s, want %s", state.Phase(), next)
+			}
+		}
+	})
+
+	t.Run("already in phase", func(t *testing.T) {
+		state := NewState(PhaseOpen, "", "")
+		if err := state.TransitionAndAct(PhaseOpen, noop); !errors.Is(err, e.ErrAlreadyInPhase) {
+			t.Errorf("got %v, want %v", err, e.ErrAlreadyInPhase)
+		}
+	})
+
+	t.Run("illegal transitions", func(t *testing.T) {
+		tests := []struct {
+			from, to Phase
+		}{
+			{PhaseSetup, PhasePaused},
+			{PhaseSetup, PhaseFinalized},
+			{PhasePaused, PhaseSetup},
+			{PhaseClosed, PhaseSetup},
+			{PhaseFinalized, PhaseOpen},
+		}
+		for _, tt := range tests {
+			state := NewState(tt.from, "", "")
+			if err := state.TransitionAndAct(tt.to, noop); !errors.Is(err, e.ErrIllegalPhaseTransition) {
+				t.Errorf("%s -> %s: got %v, want %v", tt.from, tt.to, err, e.ErrIllegalPhaseTransition)
+			}
+			if state.Phase() != tt.from {
+				t.Errorf("%s -> %s: phase changed to %s", tt.from, tt.to, state.Phase())
+			}
+		}
+	})
+
+	t.Run("failed act keeps phase", func(t *testing.T) {
+		state := NewState(PhaseSetup, "", "")
+		actErr := errors.New("worker start failed")
+		if err := state.TransitionAndAct(PhaseOpen, func(Phase) error { return actErr }); !errors.Is(err, actErr) {
+			t.Errorf("got %v, want %v", err, actErr)
+		}
+		if state.Phase() != PhaseSetup {
+			t.Errorf("phase: got %s, want %s", state.Phase(), PhaseSetup)
+		}
+	})
+}
+
+func TestRunInPhase(t *testing.T) {
+	state := NewState(PhasePaused, "", "")
+
+	ran := false
+	err := state.RunInPhase(func() error { ran = true; return nil }, PhaseSetup)
+	if !errors.Is(err, e.ErrInvalidRegistrationPhase) || ran {
+		t.Errorf("reset-like action must not run while paused: err=%v ran=%v", err, ran)
+	}
+
+	if err := state.RunInPhase(func() error { ran = true; return nil }, PhaseSetup, PhasePaused); err != nil || !ran {
+		t.Errorf("action allowed while paused should run: err=%v ran=%v", err, ran)
+	}
+}
+
+func TestSwitchTermAndAct(t *testing.T) {
+	noop := func() error { return nil }
+	next := TermConfig{Phase: PhaseSetup, Mode: ModeLottery, StartTime: "2025-08-20-09-00", EndTime: "2025-08-25-18-00"}
+
+	t.Run("switch from finalized", func(t *testing.T) {
+		state := NewState(PhaseFinalized, "2025-02-20-09-00", "2025-02-25-18-00")
+		state.SetTerm(1)
+		if err := state.SwitchTermAndAct(2, next, noop); err != nil {
+			t.Fatalf("switch: %v", err)
+		}
+		if state.Term() != 2 || state.Phase() != PhaseSetup || state.Mode() != ModeLottery {
+			t.Errorf("got term %d, phase %s, mode %s", state.Term(), state.Phase(), state.Mode())
+		}
+		if start, end := state.GetPeriod(); start != next.StartTime || end != next.EndTime {
+			t.Errorf("period: got %s ~ %s", start, end)
+		}
+	})
+
+	t.Run("not while registration runs", func(t *testing.T) {
+		for _, phase := range []Phase{PhaseOpen, PhasePaused, PhaseClosed} {
+			state := NewState(phase, "", "")
+			state.SetTerm(1)
+			if err := state.SwitchTermAndAct(2, next, noop); !errors.Is(err, e.ErrInvalidRegistrationPhase) {
+				t.Errorf("%s: got %v, want %v", phase, err, e.ErrInvalidRegistrationPhase)
+			}
+			if state.Term() != 1 {
+				t.Errorf("%s: term changed to %d", phase, state.Term())
+			}
+		}
+	})
+
+	t.Run("failed act keeps term", func(t *testing.T) {
+		state := NewState(PhaseSetup, "", "")
+		state.SetTerm(1)
+		actErr := errors.New("save failed")
+		if err := state.SwitchTermAndAct(2, next, func() error { return actErr }); !errors.Is(err, actErr) {
+			t.Errorf("got %v, want %v", err, actErr)
+		}
+		if state.Term() != 1 {
+			t.Errorf("term changed to %d", state.Term())
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package registration
+
+import (
+	"course-reg/internal/app/domain/e"
+	"fmt"
+	"time"
+)
+
+// RoundOps is the set of operations students may perform during a round
+type RoundOps string
+
+const (
+	OpsEnrollOnly   RoundOps = "ENROLL_ONLY"   // 신청만 가능
+	OpsEnrollCancel RoundOps = "ENROLL_CANCEL" // 신청 + 취소 가능
+	OpsCancelOnly   RoundOps = "CANCEL_ONLY"   // 취소만 가능
+)
+
+// Operation is a student operation checked against the active round
+type Operation int
+
+const (
+	OpEnroll Operation = iota + 1
+	OpCancel
+)
+
+func (o Operation) String() string {
+	switch o {
+	case OpEnroll:
+		return "enroll"
+	case OpCancel:
+		return "cancel"
+	default:
+		return "unknown"
+	}
+}
+
+// IsValid checks if the ops is one of the known sets
+func (ops RoundOps) IsValid() bool {
+	switch ops {
+	case OpsEnrollOnly, OpsEnrollCancel, OpsCancelOnly:
+		return true
+	default:
+		return false
+	}
+}
+
+// Allows checks if the operation is permitted by the ops
+func (ops RoundOps) Allows(op Operation) bool {
+	switch op {
+	case OpEnroll:
+		return ops == OpsEnrollOnly || ops == OpsEnrollCancel
+	case OpCancel:
+		return ops == OpsCancelOnly || ops == OpsEnrollCancel
+	default:
+		return false
+	}
+}
+
+// Round is a registration round with its own window, eligible students and allowed operations
+type Round struct {
+	ID        uint
+	Name      string
+	Start     time.Time
+	End       time.Time
+	Ops       RoundOps
+	OpenToAll bool
+	eligible  map[uint]struct{}
+}
+
+func NewRound(id uint, name string, start, end time.Time, ops RoundOps, openToAll bool, eligibleStudentIDs []uint) Round {
+	eligible := make(map[uint]struct{}, len(eligibleStudentIDs))
+	for _, studentID := range eligibleStudentIDs {
+		eligible[studentID] = struct{}{}
+	}
+	return Round{
+		ID:        id,
+		Name:      name,
+		Start:     start,
+		End:       end,
+		Ops:       ops,
+		OpenToAll: openToAll,
+		eligible:  eligible,
+	}
+}
+
+// IsActive checks if the given time is within the round window
+func (r Round) IsActive(now time.Time) bool {
+	return !now.Before(r.Start) && now.Before(r.End)
+}
+
+// IsEligible checks if the student may take part in the round
+func (r Round) IsEligible(studentID uint) bool {
+	if r.OpenToAll {
+		return true
+	}
+	_, exists := r.eligible[studentID]
+	return exists
+}
+
+// Overlaps checks if the two round windows share any time; a round may start when another ends
+func (r Round) Overlaps(other Round) bool {
+	return r.Start.Before(other.End) && other.Start.Before(r.End)
+}
+
+// FindOverlap returns a round of others, other than round itself, whose window overlaps round's.
+// Overlapping rounds are rejected, since a student could then be in either of them.
+func FindOverlap(round Round, others []Round) (Round, bool) {
+	for _, other := range others {
+		if other.ID != round.ID && round.Overlaps(other) {
+			return other, true
+		}
+	}
+	return Round{}, false
+}
+
+// SetRounds replaces the registration rounds
+func (rs *State) SetRounds(rounds []Round) {
+	rs.ruleMu.Lock()
+	defer rs.ruleMu.Unlock()
+	rs.rounds = rounds
+}
+
+// ActiveRound returns the round whose window contains now
+func (rs *State) ActiveRound(now time.Time) (Round, bool) {
+	rs.ruleMu.RLock()
+	defer rs.ruleMu.RUnlock()
+	return rs.activeRound(now)
+}
+
+func (rs *State) activeRound(now time.Time) (Round, bool) {
+	for _, round := range rs.rounds {
+		if round.IsActive(now) {
+			return round, true
+		}
+	}
+	return Round{}, false
+}
+
+// CheckRoundAccess checks if the student may perform op at now.
+// Without any rounds configured, registration is a single round open to everyone.
+func (rs *State) CheckRoundAccess(now time.Time, studentID uint, op Operation) error {
+	rs.ruleMu.RLock()
+	defer rs.ruleMu.RUnlock()
+
+	if len(rs.rounds) == 0 {
+		return nil
+	}
+
+	round, ok := rs.activeRound(now)
+	if !ok {
+		return fmt.Errorf("no active registration round: %w", e.ErrInvalidRegistrationPeriod)
+	}
+	if !round.IsEligible(studentID) {
+		return fmt.Errorf("student %d is not eligible for round %q: %w", studentID, round.Name, e.ErrNotEligibleForRound)
+	}
+	if !round.Ops.Allows(op) {
+		return fmt.Errorf("%s is not allowed in round %q: %w", op, round.Name, e.ErrOperationNotAllowed)
+	}
+	return nil
+}
+
+// CheckRoundEligibility checks only whether the student belongs to the active round, ignoring its
+// allowed operations. Outside any round (or without rounds) every student is eligible.
+func (rs *State) CheckRoundEligibility(now time.Time, studentID uint) error {
+	rs.ruleMu.RLock()
+	defer rs.ruleMu.RUnlock()
+
+	round, ok := rs.activeRound(now)
+	if !ok || round.IsEligible(studentID) {
+		return nil
+	}
+	return fmt.Errorf("student %d is not eligible for round %q: %w", studentID, round.Name, e.ErrNotEligibleForRound)
+}
@@ -0,0 +1,84 @@
+package registration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"course-reg/internal/app/domain/e"
+)
+
+func TestCheckRoundAccess(t *testing.T) {
+	base := time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)
+	state := NewState(PhaseOpen, "", "")
+
+	// Without rounds everyone can do everything
+	if err := state.CheckRoundAccess(base, 1, OpCancel); err != nil {
+		t.Fatalf("no rounds: unexpected error %v", err)
+	}
+
+	state.SetRounds([]Round{
+		NewRound(1, "priority", base, base.Add(time.Hour), OpsEnrollOnly, false, []uint{1}),
+		NewRound(2, "general", base.Add(2*time.Hour), base.Add(3*time.Hour), OpsEnrollCancel, true, nil),
+		NewRound(3, "drop", base.Add(3*time.Hour), base.Add(4*time.Hour), OpsCancelOnly, true, nil),
+	})
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		studentID uint
+		op        Operation
+		want      error
+	}{
+		{"priority student enrolls", base, 1, OpEnroll, nil},
+		{"other student in priority round", base, 2, OpEnroll, e.ErrNotEligibleForRound},
+		{"cancel in enroll-only round", base, 1, OpCancel, e.ErrOperationNotAllowed},
+		{"between rounds", base.Add(90 * time.Minute), 1, OpEnroll, e.ErrInvalidRegistrationPeriod},
+		{"general round cancel", base.Add(2 * time.Hour), 2, OpCancel, nil},
+		{"enroll in drop round", base.Add(3 * time.Hour), 2, OpEnroll, e.ErrOperationNotAllowed},
+		{"cancel in drop round", base.Add(3 * time.Hour), 2, OpCancel, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := state.CheckRoundAccess(tt.now, tt.studentID, tt.op)
+			if tt.want == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindOverlap(t *testing.T) {
+	base := time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)
+	rounds := []Round{
+		NewRound(1, "priority", base, base.Add(time.Hour), OpsEnrollOnly, true, nil),
+		NewRound(2, "general", base.Add(2*time.Hour), base.Add(3*time.Hour), OpsEnrollCancel, true, nil),
+	}
+
+	tests := []struct {
+		name    string
+		round   Round
+		overlap uint // 0 means none
+	}{
+		{"between rounds", NewRound(0, "new", base.Add(time.Hour), base.Add(2*time.Hour), OpsCancelOnly, true, nil), 0},
+		{"inside a round", NewRound(0, "new", base.Add(10*time.Minute), base.Add(20*time.Minute), OpsCancelOnly, true, nil), 1},
+		{"spanning a round", NewRound(0, "new", base.Add(90*time.Minute), base.Add(4*time.Hour), OpsCancelOnly, true, nil), 2},
+		{"updated round keeps its window", NewRound(2, "general", base.Add(2*time.Hour), base.Add(4*time.Hour), OpsEnrollCancel, true, nil), 0},
+		{"updated round moved onto another", NewRound(2, "general", base.Add(30*time.Minute), base.Add(3*time.Hour), OpsEnrollCancel, true, nil), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other, ok := FindOverlap(tt.round, rounds)
+			if tt.overlap == 0 && ok {
+				t.Errorf("unexpected overlap with round %d", other.ID)
+			}
+			if tt.overlap != 0 && (!ok || other.ID != tt.overlap) {
+				t.Errorf("got overlap %d (%v), want round %d", other.ID, ok, tt.overlap)
+			}
+		})
+	}
+}
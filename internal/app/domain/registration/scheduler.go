@@ -0,0 +1,133 @@
+package registration
+
+import (
+	"course-reg/internal/pkg/utils"
+	"log"
+	"sync"
+	"time"
+)
+
+type periodStatus int
+
+const (
+	periodUnknown periodStatus = iota
+	periodBefore
+	periodWithin
+	periodAfter
+)
+
+// Scheduler opens and closes registration at the boundaries of the stored period.
+// It only acts when the clock crosses a boundary (or on the first check after the period is set),
+// so a manual pause in the middle of the period is not overridden.
+// Registration is only opened from SETUP; a paused or closed registration is left to the admin.
+type Scheduler struct {
+	state    *State
+	clock    utils.TimeProvider
+	interval time.Duration
+	open     func() error
+	close    func() error
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	lastStatus periodStatus
+	lastStart  string
+	lastEnd    string
+}
+
+func NewScheduler(state *State, clock utils.TimeProvider, interval time.Duration, open, close func() error) *Scheduler {
+	return &Scheduler{
+		state:    state,
+		clock:    clock,
+		interval: interval,
+		open:     open,
+		close:    close,
+	}
+}
+
+func (s *Scheduler) Start() {
+	s.stop = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.tick()
+		for {
+			select {
+			case <-ticker.C:
+				s.tick()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) tick() {
+	startTime, endTime := s.state.GetPeriod()
+	if startTime != s.lastStart || endTime != s.lastEnd {
+		// A new period is judged from scratch
+		s.lastStatus = periodUnknown
+		s.lastStart, s.lastEnd = startTime, endTime
+	}
+	if startTime == "" || endTime == "" {
+		return
+	}
+
+	status, err := s.status(endTime)
+	if err != nil {
+		log.Println("[error] registration scheduler:", err.Error())
+		return
+	}
+	if status == s.lastStatus {
+		return
+	}
+
+	// A failed open or close leaves lastStatus as it was, so the next tick tries again
+	switch status {
+	case periodWithin:
+		if s.state.Phase() == PhaseSetup {
+			log.Println("[info] registration period started, opening registration")
+			if err := s.open(); err != nil {
+				log.Println("[error] scheduled registration open failed:", err.Error())
+				return
+			}
+		}
+	case periodAfter:
+		if phase := s.state.Phase(); phase == PhaseOpen || phase == PhasePaused {
+			log.Println("[info] registration period ended, closing registration")
+			if err := s.close(); err != nil {
+				log.Println("[error] scheduled registration close failed:", err.Error())
+				return
+			}
+		}
+	}
+	s.lastStatus = status
+}
+
+func (s *Scheduler) status(endTime string) (periodStatus, error) {
+	now := s.clock.Now()
+	within, err := s.state.IsWithinRegistrationPeriod(now)
+	if err != nil {
+		return periodUnknown, err
+	}
+	if within {
+		return periodWithin, nil
+	}
+
+	end, err := utils.StringToTime(endTime)
+	if err != nil {
+		return periodUnknown, err
+	}
+	if !now.Before(end) {
+		return periodAfter, nil
+	}
+	return periodBefore, nil
+}
@@ -0,0 +1,132 @@
+package registration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"course-reg/internal/pkg/utils"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	tm, err := utils.StringToTime(s)
+	if err != nil {
+		t.Fatalf("parse time %q: %v", s, err)
+	}
+	return tm
+}
+
+// newTestScheduler wires open/close callbacks that flip the state like AdminService does
+func newTestScheduler(state *State, clock *fakeClock) (*Scheduler, *int, *int) {
+	opened, closed := 0, 0
+	open := func() error {
+		opened++
+		return state.TransitionAndAct(PhaseOpen, func(Phase) error { return nil })
+	}
+	close := func() error {
+		closed++
+		return state.TransitionAndAct(PhaseClosed, func(Phase) error { return nil })
+	}
+	return NewScheduler(state, clock, time.Second, open, close), &opened, &closed
+}
+
+func TestSchedulerOpensAndClosesAtBoundaries(t *testing.T) {
+	state := NewState(PhaseSetup, "2025-01-20-09-00", "2025-01-25-18-00")
+	clock := &fakeClock{now: mustTime(t, "2025-01-20-08-59")}
+	s, opened, closed := newTestScheduler(state, clock)
+
+	s.tick()
+	if state.Phase() != PhaseSetup || *opened != 0 {
+		t.Fatalf("registration should stay closed before the period")
+	}
+
+	clock.now = mustTime(t, "2025-01-20-09-00")
+	s.tick()
+	if !state.IsOpen() || *opened != 1 {
+		t.Fatalf("registration should open at the start time")
+	}
+
+	clock.now = mustTime(t, "2025-01-25-18-00")
+	s.tick()
+	if state.Phase() != PhaseClosed || *closed != 1 {
+		t.Fatalf("registration should close at the end time")
+	}
+}
+
+func TestSchedulerRespectsManualPause(t *testing.T) {
+	state := NewState(PhaseSetup, "2025-01-20-09-00", "2025-01-25-18-00")
+	clock := &fakeClock{now: mustTime(t, "2025-01-21-12-00")}
+	s, opened, _ := newTestScheduler(state, clock)
+
+	// Restart in the middle of the period opens registration
+	s.tick()
+	if !state.IsOpen() || *opened != 1 {
+		t.Fatalf("registration should open when started within the period")
+	}
+
+	// Admin pauses for an incident; the scheduler must not reopen
+	if err := state.TransitionAndAct(PhasePaused, func(Phase) error { return nil }); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	clock.now = clock.now.Add(time.Minute)
+	s.tick()
+	if state.Phase() != PhasePaused || *opened != 1 {
+		t.Fatalf("scheduler should not override a manual pause")
+	}
+}
+
+func TestSchedulerReevaluatesNewPeriod(t *testing.T) {
+	state := NewState(PhaseSetup, "", "")
+	clock := &fakeClock{now: mustTime(t, "2025-01-21-12-00")}
+	s, opened, _ := newTestScheduler(state, clock)
+
+	s.tick()
+	if *opened != 0 {
+		t.Fatalf("no period set, nothing should happen")
+	}
+
+	state.SetPeriod("2025-01-21-11-00", "2025-01-21-13-00")
+	s.tick()
+	if !state.IsOpen() || *opened != 1 {
+		t.Fatalf("registration should open once a period covering now is set")
+	}
+}
+
+func TestSchedulerRetriesFailedOpen(t *testing.T) {
+	state := NewState(PhaseSetup, "2025-01-20-09-00", "2025-01-25-18-00")
+	clock := &fakeClock{now: mustTime(t, "2025-01-20-09-00")}
+	attempts := 0
+	open := func() error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("load init data failed")
+		}
+		return state.TransitionAndAct(PhaseOpen, func(Phase) error { return nil })
+	}
+	s := NewScheduler(state, clock, time.Second, open, func() error { return nil })
+
+	s.tick()
+	if state.Phase() != PhaseSetup || attempts != 1 {
+		t.Fatalf("first open should fail (attempts: %d)", attempts)
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	s.tick()
+	if !state.IsOpen() || attempts != 2 {
+		t.Fatalf("scheduler should retry a failed open (attempts: %d)", attempts)
+	}
+
+	s.tick()
+	if attempts != 2 {
+		t.Fatalf("scheduler should not open again once it succeeded (attempts: %d)", attempts)
+	}
+}
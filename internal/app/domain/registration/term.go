@@ -0,0 +1,60 @@
+package registration
+
+import (
+	"course-reg/internal/app/domain/e"
+	"fmt"
+)
+
+// TermConfig is the stored registration config of a term
+type TermConfig struct {
+	Phase     Phase
+	Mode      Mode
+	StartTime string
+	EndTime   string
+}
+
+// Term returns the active term
+func (rs *State) Term() uint {
+	rs.ruleMu.RLock()
+	defer rs.ruleMu.RUnlock()
+	return rs.term
+}
+
+// SetTerm sets the active term without checks (used when loading the stored config)
+func (rs *State) SetTerm(termID uint) {
+	rs.ruleMu.Lock()
+	defer rs.ruleMu.Unlock()
+	rs.term = termID
+}
+
+// SwitchTermAndAct makes termID the active term and loads its config, while no registration is
+// running in either term (SETUP or FINALIZED). act runs before anything changes, e.g. to persist the switch.
+func (rs *State) SwitchTermAndAct(termID uint, config TermConfig, act func() error) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if !isIdle(rs.phase) {
+		return fmt.Errorf("term cannot change in phase %s: %w", rs.phase, e.ErrInvalidRegistrationPhase)
+	}
+	if !isIdle(config.Phase) {
+		return fmt.Errorf("term %d is in phase %s: %w", termID, config.Phase, e.ErrIllegalPhaseTransition)
+	}
+
+	if err := act(); err != nil {
+		return err
+	}
+
+	rs.phase = config.Phase
+	rs.ruleMu.Lock()
+	defer rs.ruleMu.Unlock()
+	rs.term = termID
+	rs.mode = config.Mode
+	rs.startTime = config.StartTime
+	rs.endTime = config.EndTime
+	return nil
+}
+
+// isIdle checks if no registration is running or pending in the phase
+func isIdle(phase Phase) bool {
+	return phase == PhaseSetup || phase == PhaseFinalized
+}
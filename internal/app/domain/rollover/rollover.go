@@ -0,0 +1,178 @@
+package rollover
+
+import (
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/models"
+	"fmt"
+)
+
+// Options describe a rollover: the new term's name, the term to copy, and what to change on the way
+type Options struct {
+	Name              string       `json:"name" binding:"required"`
+	SourceTermID      uint         `json:"source_term_id" binding:"required"`
+	Courses           []Adjustment `json:"courses"`
+	ExcludeStudentIDs []uint       `json:"exclude_student_ids"` // e.g. graduates
+}
+
+// Adjustment changes one course while it is copied; nil fields keep the source value
+type Adjustment struct {
+	SourceCourseID uint    `json:"source_course_id" binding:"required"`
+	Exclude        bool    `json:"exclude"` // do not copy the course
+	Capacity       *int    `json:"capacity"`
+	Schedules      *string `json:"schedules"`
+}
+
+// PlannedCourse is a course that will be created in the new term
+type PlannedCourse struct {
+	SourceCourseID uint          `json:"source_course_id"`
+	Adjusted       bool          `json:"adjusted"`
+	Course         models.Course `json:"course"`
+}
+
+// Plan is what a rollover creates. A plan with problems is only a preview and cannot be committed.
+type Plan struct {
+	Courses         []PlannedCourse    `json:"courses"`
+	ExcludedCourses []uint             `json:"excluded_course_ids"`
+	Rules           models.CourseRules `json:"rules"` // rules of the copied courses, still with the source course IDs
+	StudentIDs      []uint             `json:"student_ids"`
+	Problems        []string           `json:"problems"`
+}
+
+// Build plans copying the source term's courses, the rules of those courses and its roster into a new term.
+// Every copied schedule is validated, including unchanged ones, so typos carried over from the source term
+// show up as problems. Prerequisites are kept by course code and need no copy.
+func Build(courses []models.Course, rules models.CourseRules, studentIDs []uint, adjustments []Adjustment, excludeStudentIDs []uint) Plan {
+	plan := Plan{Courses: []PlannedCourse{}, ExcludedCourses: []uint{}, StudentIDs: []uint{}, Problems: []string{}}
+
+	byCourse := make(map[uint]Adjustment, len(adjustments))
+	for _, adj := range adjustments {
+		if _, ok := byCourse[adj.SourceCourseID]; ok {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("course %d: adjusted more than once", adj.SourceCourseID))
+		}
+		byCourse[adj.SourceCourseID] = adj
+	}
+
+	known := make(map[uint]bool, len(courses))
+	for _, source := range courses {
+		known[source.ID] = true
+		adj, adjusted := byCourse[source.ID]
+		if adj.Exclude {
+			plan.ExcludedCourses = append(plan.ExcludedCourses, source.ID)
+			continue
+		}
+
+		course := source
+		course.ID = 0
+		course.TermID = 0
+		if adj.Capacity != nil {
+			course.Capacity = *adj.Capacity
+		}
+		if adj.Schedules != nil {
+			course.Schedules = *adj.Schedules
+		}
+
+		if course.Capacity <= 0 {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("course %d (%s): capacity must be positive", source.ID, source.Name))
+		}
+		if err := cache.ValidateSchedule(course.Schedules); err != nil {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("course %d (%s): %v", source.ID, source.Name, err))
+		}
+		plan.Courses = append(plan.Courses, PlannedCourse{SourceCourseID: source.ID, Adjusted: adjusted, Course: course})
+	}
+
+	for _, adj := range adjustments {
+		if !known[adj.SourceCourseID] {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("course %d: not in the source term", adj.SourceCourseID))
+		}
+	}
+
+	plan.Rules = copiedRules(plan.Courses, rules)
+
+	excluded := make(map[uint]bool, len(excludeStudentIDs))
+	for _, studentID := range excludeStudentIDs {
+		excluded[studentID] = true
+	}
+	for _, studentID := range studentIDs {
+		if !excluded[studentID] {
+			plan.StudentIDs = append(plan.StudentIDs, studentID)
+		}
+	}
+	return plan
+}
+
+// NewCourses returns the courses to insert
+func (p Plan) NewCourses() []models.Course {
+	courses := make([]models.Course, len(p.Courses))
+	for i, planned := range p.Courses {
+		courses[i] = planned.Course
+	}
+	return courses
+}
+
+// copiedRules returns the rules that follow the planned courses. Groups keep only their copied courses
+// and are dropped once fewer than two are left; other rules are dropped with their course.
+func copiedRules(planned []PlannedCourse, rules models.CourseRules) models.CourseRules {
+	copied := make(map[uint]bool, len(planned))
+	for _, p := range planned {
+		copied[p.SourceCourseID] = true
+	}
+
+	result := models.CourseRules{
+		CourseGroups:     []models.CourseGroup{},
+		SpecialRules:     []models.SpecialCourseRule{},
+		EligibilityRules: []models.EligibilityRule{},
+	}
+	for _, group := range rules.CourseGroups {
+		var courseIDs []uint
+		for _, courseID := range group.CourseIDs {
+			if copied[courseID] {
+				courseIDs = append(courseIDs, courseID)
+			}
+		}
+		if len(courseIDs) >= 2 {
+			group.CourseIDs = courseIDs
+			result.CourseGroups = append(result.CourseGroups, group)
+		}
+	}
+	for _, rule := range rules.SpecialRules {
+		if copied[rule.CourseID] {
+			result.SpecialRules = append(result.SpecialRules, rule)
+		}
+	}
+	for _, rule := range rules.EligibilityRules {
+		if copied[rule.CourseID] {
+			result.EligibilityRules = append(result.EligibilityRules, rule)
+		}
+	}
+	return result
+}
+
+// CopyRules returns new rows for the plan's rules, pointing at created: the result of inserting NewCourses,
+// with their new IDs in the same order
+func (p Plan) CopyRules(created []models.Course) models.CourseRules {
+	newIDs := make(map[uint]uint, len(p.Courses))
+	for i, planned := range p.Courses {
+		newIDs[planned.SourceCourseID] = created[i].ID
+	}
+
+	var rules models.CourseRules
+	for _, group := range p.Rules.CourseGroups {
+		courseIDs := make([]uint, len(group.CourseIDs))
+		for i, courseID := range group.CourseIDs {
+			courseIDs[i] = newIDs[courseID]
+		}
+		group.ID = 0
+		group.CourseIDs = courseIDs
+		rules.CourseGroups = append(rules.CourseGroups, group)
+	}
+	for _, rule := range p.Rules.SpecialRules {
+		rule.CourseID = newIDs[rule.CourseID]
+		rules.SpecialRules = append(rules.SpecialRules, rule)
+	}
+	for _, rule := range p.Rules.EligibilityRules {
+		rule.ID = 0
+		rule.CourseID = newIDs[rule.CourseID]
+		rules.EligibilityRules = append(rules.EligibilityRules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,105 @@
+package rollover
+
+import (
+	"course-reg/internal/app/models"
+	"slices"
+	"testing"
+)
+
+func intPtr(v int) *int       { return &v }
+func strPtr(v string) *string { return &v }
+
+func testCourses() []models.Course {
+	return []models.Course{
+		{ID: 10, TermID: 1, Name: "A", Capacity: 20, Schedules: "월 09:00~10:00", Credits: 2},
+		{ID: 11, TermID: 1, Name: "B", Capacity: 30, Schedules: "화 09:00~10:00"},
+		{ID: 12, TermID: 1, Name: "C", Capacity: 10, Schedules: "수 09:00~10:00"},
+	}
+}
+
+func TestBuildCopiesWithAdjustments(t *testing.T) {
+	adjustments := []Adjustment{
+		{SourceCourseID: 10, Capacity: intPtr(25)},
+		{SourceCourseID: 11, Schedules: strPtr("목 13:00~15:00")},
+		{SourceCourseID: 12, Exclude: true},
+	}
+	plan := Build(testCourses(), models.CourseRules{}, []uint{1, 2, 3}, adjustments, []uint{2})
+
+	if len(plan.Problems) != 0 {
+		t.Fatalf("unexpected problems: %v", plan.Problems)
+	}
+	if len(plan.Courses) != 2 {
+		t.Fatalf("courses: got %d, want 2", len(plan.Courses))
+	}
+	a, b := plan.Courses[0].Course, plan.Courses[1].Course
+	if a.ID != 0 || a.TermID != 0 {
+		t.Errorf("copied course should not keep its ID or term: %+v", a)
+	}
+	if a.Name != "A" || a.Capacity != 25 || a.Schedules != "월 09:00~10:00" || a.Credits != 2 {
+		t.Errorf("course A: got %+v", a)
+	}
+	if b.Capacity != 30 || b.Schedules != "목 13:00~15:00" {
+		t.Errorf("course B: got %+v", b)
+	}
+	if !slices.Equal(plan.ExcludedCourses, []uint{12}) {
+		t.Errorf("excluded: got %v, want [12]", plan.ExcludedCourses)
+	}
+	if !slices.Equal(plan.StudentIDs, []uint{1, 3}) {
+		t.Errorf("students: got %v, want [1 3]", plan.StudentIDs)
+	}
+}
+
+func TestBuildReportsProblems(t *testing.T) {
+	courses := testCourses()
+	courses[2].Schedules = "수 19:00~09:00" // typo carried over from the source term
+	adjustments := []Adjustment{
+		{SourceCourseID: 10, Capacity: intPtr(0)},
+		{SourceCourseID: 11, Schedules: strPtr("목 1300~15:00")},
+		{SourceCourseID: 99, Exclude: true},
+	}
+	plan := Build(courses, models.CourseRules{}, nil, adjustments, nil)
+
+	if len(plan.Problems) != 4 {
+		t.Errorf("problems: got %d, want 4: %v", len(plan.Problems), plan.Problems)
+	}
+}
+
+func TestBuildCopiesRulesToNewCourses(t *testing.T) {
+	rules := models.CourseRules{
+		CourseGroups: []models.CourseGroup{
+			{ID: 1, Name: "A+B", Type: "COREQUISITE", CourseIDs: []uint{10, 11}},
+			{ID: 2, Name: "A+C", Type: "EXCLUSIVE", CourseIDs: []uint{10, 12}}, // C is excluded
+			{ID: 3, Name: "old term", Type: "EXCLUSIVE", CourseIDs: []uint{1, 2}},
+		},
+		SpecialRules: []models.SpecialCourseRule{
+			{CourseID: 11, StudentIDs: []uint{1}},
+			{CourseID: 12, Cohorts: []string{"A"}},
+		},
+		EligibilityRules: []models.EligibilityRule{
+			{ID: 5, CourseID: 10, Type: "MIN_AGE", Age: 19},
+			{ID: 6, CourseID: 3, Type: "MIN_AGE", Age: 19},
+		},
+	}
+	plan := Build(testCourses(), rules, nil, []Adjustment{{SourceCourseID: 12, Exclude: true}}, nil)
+
+	if len(plan.Rules.CourseGroups) != 1 || len(plan.Rules.SpecialRules) != 1 || len(plan.Rules.EligibilityRules) != 1 {
+		t.Fatalf("copied rules: got %+v", plan.Rules)
+	}
+
+	created := plan.NewCourses()
+	created[0].ID, created[1].ID = 110, 111
+	copied := plan.CopyRules(created)
+
+	if group := copied.CourseGroups[0]; group.ID != 0 || !slices.Equal(group.CourseIDs, []uint{110, 111}) {
+		t.Errorf("course group: got %+v", group)
+	}
+	if rule := copied.SpecialRules[0]; rule.CourseID != 111 || !slices.Equal(rule.StudentIDs, []uint{1}) {
+		t.Errorf("special rule: got %+v", rule)
+	}
+	if rule := copied.EligibilityRules[0]; rule.ID != 0 || rule.CourseID != 110 || rule.Age != 19 {
+		t.Errorf("eligibility rule: got %+v", rule)
+	}
+	if !slices.Equal(plan.Rules.CourseGroups[0].CourseIDs, []uint{10, 11}) {
+		t.Errorf("plan should keep the source course IDs: got %v", plan.Rules.CourseGroups[0].CourseIDs)
+	}
+}
@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"context"
+	"course-reg/internal/app/domain/cache"
+)
+
+// AdminEnroll enrolls a student on behalf of an admin, skipping the rules set in overrides
+func (w *EnrollmentWorker) AdminEnroll(ctx context.Context, studentID, courseID uint, overrides Overrides) error {
+	return w.submitRequest(ctx, EnrollmentRequest{
+		Type:      ADMIN_ENROLL,
+		StudentID: studentID,
+		CourseID:  courseID,
+		Overrides: overrides,
+	}).Err
+}
+
+// AdminCancel cancels a student's enrollment on behalf of an admin; the freed seat goes to the waitlist
+func (w *EnrollmentWorker) AdminCancel(ctx context.Context, studentID, courseID uint) error {
+	return w.submit(ctx, ADMIN_CANCEL, studentID, courseID).Err
+}
+
+// SetStudentLimit replaces a student's limit override while the worker runs; nil removes it.
+// It applies to the student's next requests; enrollments already over a lowered limit are kept.
+func (w *EnrollmentWorker) SetStudentLimit(ctx context.Context, studentID uint, limit *cache.Limit) error {
+	return w.submitRequest(ctx, EnrollmentRequest{
+		Type:      SET_LIMIT,
+		StudentID: studentID,
+		Limit:     limit,
+	}).Err
+}
+
+// processAdminEnroll is processEnroll with optional capacity, time conflict, limit, eligibility and course group overrides
+func (w *EnrollmentWorker) processAdminEnroll(req EnrollmentRequest) error {
+	if _, ok := w.cache.HoldExpiry(req.StudentID, req.CourseID); ok {
+		return w.confirmHold(req.StudentID, req.CourseID, req.Overrides)
+	}
+	return w.enroll(req.StudentID, req.CourseID, req.Overrides)
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func TestAdminEnroll(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	students := []models.Student{{ID: 1}, {ID: 2}, {ID: 3}}
+	courses := []models.Course{
+		{ID: 10, Capacity: 1, Schedules: "월 09:00~10:00"},
+		{ID: 20, Capacity: 1, Schedules: "월 09:30~10:30"}, // conflicts with 10
+	}
+	w := startTestWorker(t, repo, students, courses)
+
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+
+	if err := w.AdminEnroll(ctx, 2, 10, Overrides{}); !errors.Is(err, e.ErrCourseFull) {
+		t.Errorf("without override: got %v, want %v", err, e.ErrCourseFull)
+	}
+	if err := w.AdminEnroll(ctx, 2, 10, Overrides{IgnoreCapacity: true}); err != nil {
+		t.Fatalf("ignore capacity: %v", err)
+	}
+	if got := w.cache.EnrolledCount[10].Load(); got != 2 {
+		t.Errorf("enrolled count: got %d, want 2", got)
+	}
+
+	if err := w.AdminEnroll(ctx, 1, 20, Overrides{}); !errors.Is(err, e.ErrTimeConflict) {
+		t.Errorf("without override: got %v, want %v", err, e.ErrTimeConflict)
+	}
+	if err := w.AdminEnroll(ctx, 1, 20, Overrides{IgnoreTimeConflict: true}); err != nil {
+		t.Fatalf("ignore time conflict: %v", err)
+	}
+
+	// Over capacity, a cancel must not promote anyone from the waitlist
+	if _, err := w.JoinWaitlist(ctx, 3, 10); err != nil {
+		t.Fatalf("join waitlist: %v", err)
+	}
+	if err := w.AdminCancel(ctx, 1, 10); err != nil {
+		t.Fatalf("admin cancel: %v", err)
+	}
+	if w.cache.IsStudentEnrolled(3, 10) {
+		t.Errorf("student 3 should not be promoted while the course is still full")
+	}
+	if err := w.AdminCancel(ctx, 2, 10); err != nil {
+		t.Fatalf("admin cancel: %v", err)
+	}
+	if !w.cache.IsStudentEnrolled(3, 10) {
+		t.Errorf("student 3 should be promoted once a seat is free")
+	}
+}
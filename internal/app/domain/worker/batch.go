@@ -0,0 +1,174 @@
+package worker
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"fmt"
+	"log"
+)
+
+// pendingEnroll is an enrollment already applied to the cache whose row is not written yet
+type pendingEnroll struct {
+	row      models.Enrollment
+	response chan EnrollmentResponse
+}
+
+// drain collects first and up to batchSize-1 more requests already waiting in the queue, without blocking
+func (w *EnrollmentWorker) drain(requestChan chan EnrollmentRequest, first EnrollmentRequest) []EnrollmentRequest {
+	reqs := []EnrollmentRequest{first}
+	for len(reqs) < w.batchSize {
+		select {
+		case req, ok := <-requestChan:
+			if !ok {
+				return reqs
+			}
+			reqs = append(reqs, req)
+		default:
+			return reqs
+		}
+	}
+	return reqs
+}
+
+// processBatch handles the requests in queue order. Plain enrollments are decided against the cache right away,
+// so later requests see their seats, but their rows are written together and they are answered only after that.
+// Any other request first writes the pending rows, so DB changes keep the order of the requests.
+// Plain enrollments and cancellations run with mu held shared, everything else with mu held exclusively.
+func (w *EnrollmentWorker) processBatch(reqs []EnrollmentRequest) {
+	var pending []pendingEnroll
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for _, req := range reqs {
+		if err := req.Ctx.Err(); err != nil {
+			req.Response <- EnrollmentResponse{Err: fmt.Errorf("%w: %w", e.ErrRequestAbandoned, err)}
+			continue
+		}
+
+		switch req.Type {
+		case ENROLL:
+			row, ok, err := w.decideEnroll(req)
+			if err != nil {
+				req.Response <- EnrollmentResponse{Err: err}
+				continue
+			}
+			if ok {
+				pending = append(pending, pendingEnroll{row: row, response: req.Response})
+				continue
+			}
+		case CANCEL:
+			if w.isPlainCancel(req) {
+				w.commitPending(pending)
+				pending = nil
+				req.Response <- EnrollmentResponse{Err: w.processCancel(req)}
+				continue
+			}
+		}
+
+		w.commitPending(pending)
+		pending = nil
+
+		w.mu.RUnlock()
+		w.mu.Lock()
+		resp := w.process(req)
+		w.mu.Unlock()
+		w.mu.RLock()
+		req.Response <- resp
+	}
+
+	w.commitPending(pending)
+}
+
+// decideEnroll checks a plain enrollment and takes the seat in the cache, returning the row to write.
+// ok is false for enrollments that need more than one new row (held seats, co-requisites, waitlist promotion);
+// those go through processEnroll instead.
+// Another shard may take the last seat between the check and the claim, which fails with ErrCourseFull.
+func (w *EnrollmentWorker) decideEnroll(req EnrollmentRequest) (row models.Enrollment, ok bool, err error) {
+	if _, held := w.cache.HoldExpiry(req.StudentID, req.CourseID); held {
+		return row, false, nil
+	}
+
+	pos, err := w.checkEnroll(req.StudentID, req.CourseID, Overrides{})
+	if err != nil {
+		return row, false, err
+	}
+
+	if len(w.cache.MissingCorequisites(req.StudentID, req.CourseID)) > 0 || w.cache.IsStudentWaiting(req.StudentID, req.CourseID) {
+		return row, false, nil
+	}
+
+	if pos, ok = w.cache.TakeSeat(req.StudentID, req.CourseID); !ok {
+		return row, false, e.ErrCourseFull
+	}
+	return models.Enrollment{TermID: w.cache.TermID, StudentID: req.StudentID, CourseID: req.CourseID, Position: pos}, true, nil
+}
+
+// isPlainCancel reports whether a cancellation frees seats without promoting anyone from a waitlist,
+// so it only touches the student's own courses
+func (w *EnrollmentWorker) isPlainCancel(req EnrollmentRequest) bool {
+	if !w.cache.CourseExists(req.CourseID) || !w.cache.StudentExists(req.StudentID) {
+		return true // rejected without changes
+	}
+	if len(w.cache.StudentWaitingCourses[req.StudentID]) > 0 {
+		return false
+	}
+	for _, courseID := range append([]uint{req.CourseID}, w.cache.EnrolledCorequisites(req.StudentID, req.CourseID)...) {
+		if w.cache.WaitingCount[courseID].Load() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// commitPending writes the pending rows in one batch, to the journal if there is one, and answers their requests.
+// If the batch fails, the cache changes are rolled back and each row is retried on its own,
+// so one bad row does not fail the others.
+func (w *EnrollmentWorker) commitPending(pending []pendingEnroll) {
+	if len(pending) == 0 {
+		return
+	}
+
+	rows := make([]models.Enrollment, len(pending))
+	for i, p := range pending {
+		rows[i] = p.row
+	}
+
+	if w.journal != nil {
+		err := w.journal.Append(rows)
+		if err == nil {
+			for _, p := range pending {
+				p.response <- EnrollmentResponse{}
+			}
+			return
+		}
+		log.Printf("[error] journal append of %d enrollments failed, writing them to the DB: %v", len(rows), err)
+	}
+
+	err := w.enrollRepo.BatchInsertEnrollments(rows)
+	if err == nil {
+		for _, p := range pending {
+			p.response <- EnrollmentResponse{}
+		}
+		return
+	}
+
+	log.Printf("[error] batch insert of %d enrollments failed, retrying one by one: %v", len(rows), err)
+	for _, p := range pending {
+		w.cache.CancelStudent(p.row.StudentID, p.row.CourseID)
+	}
+	for _, p := range pending {
+		// Another shard may have taken the seat while it was given back
+		pos, ok := w.cache.TakeSeat(p.row.StudentID, p.row.CourseID)
+		if !ok {
+			p.response <- EnrollmentResponse{Err: e.ErrCourseFull}
+			continue
+		}
+		p.row.Position = pos
+		if err := w.enrollRepo.InsertEnrollment(&p.row); err != nil {
+			w.cache.CancelStudent(p.row.StudentID, p.row.CourseID)
+			p.response <- EnrollmentResponse{Err: fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)}
+			continue
+		}
+		p.response <- EnrollmentResponse{}
+	}
+}
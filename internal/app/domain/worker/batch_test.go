@@ -0,0 +1,170 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func TestGroupCommit(t *testing.T) {
+	ctx := context.Background()
+	repo := newBlockingEnrollmentRepo()
+	w := NewEnrollmentWorker(10, 1, repo, nil, &testClock{})
+	data := cache.InitData{
+		Students: []models.Student{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}},
+		Courses: []models.Course{
+			{ID: 10, Capacity: 3, Schedules: "월 09:00~10:00"},
+			{ID: 20, Capacity: 5, Schedules: "월 09:30~10:30"}, // conflicts with 10
+			{ID: 30, Capacity: 5, Schedules: "화 09:00~10:00"},
+		},
+	}
+	if err := w.Start(data); err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+
+	// Student 1 occupies the worker while the rest queue up
+	first := make(chan error, 1)
+	go func() { first <- w.Enroll(ctx, 1, 10) }()
+	<-repo.entered
+
+	queued := []struct {
+		name   string
+		submit func() error
+		want   error
+	}{
+		{"enroll 2 in 10", func() error { return w.Enroll(ctx, 2, 10) }, nil},
+		{"enroll 3 in 10", func() error { return w.Enroll(ctx, 3, 10) }, nil},
+		{"enroll 4 in full 10", func() error { return w.Enroll(ctx, 4, 10) }, e.ErrCourseFull},
+		{"enroll 2 in 20 against pending 10", func() error { return w.Enroll(ctx, 2, 20) }, e.ErrTimeConflict},
+		{"enroll 3 in 30", func() error { return w.Enroll(ctx, 3, 30) }, nil},
+		{"cancel pending 3 in 10", func() error { return w.Cancel(ctx, 3, 10) }, nil},
+		{"enroll 4 in freed 10", func() error { return w.Enroll(ctx, 4, 10) }, nil},
+	}
+	results := make([]chan error, len(queued))
+	for i, q := range queued {
+		results[i] = make(chan error, 1)
+		go func() { results[i] <- q.submit() }()
+		waitQueued(w, i+1)
+	}
+
+	close(repo.release)
+	if err := <-first; err != nil {
+		t.Fatalf("enroll 1 in 10: %v", err)
+	}
+	for i, q := range queued {
+		if err := <-results[i]; !errors.Is(err, q.want) {
+			t.Errorf("%s: got %v, want %v", q.name, err, q.want)
+		}
+	}
+
+	// The cancel writes the first three successes before it runs
+	if want := []int{1, 3, 1}; !slices.Equal(repo.batchSizes, want) {
+		t.Errorf("batch sizes: got %v, want %v", repo.batchSizes, want)
+	}
+	for _, want := range [][2]uint{{1, 10}, {2, 10}, {3, 30}, {4, 10}} {
+		if repo.find(want[0], want[1], false) < 0 {
+			t.Errorf("missing enrollment row (student: %d, course: %d)", want[0], want[1])
+		}
+	}
+	if len(repo.rows) != 4 {
+		t.Errorf("rows: got %+v, want 4 rows", repo.rows)
+	}
+}
+
+func TestGroupCommitFallback(t *testing.T) {
+	ctx := context.Background()
+	students := []models.Student{{ID: 1}, {ID: 2}}
+	courses := []models.Course{{ID: 10, Capacity: 5, Schedules: "월 09:00~10:00"}}
+
+	t.Run("failed batch is retried row by row", func(t *testing.T) {
+		repo := &fakeEnrollmentRepo{failBatch: true}
+		w := startTestWorker(t, repo, students, courses)
+
+		for _, studentID := range []uint{1, 2} {
+			if err := w.Enroll(ctx, studentID, 10); err != nil {
+				t.Fatalf("enroll %d: %v", studentID, err)
+			}
+		}
+		if len(repo.rows) != 2 || w.cache.EnrolledCount[10].Load() != 2 {
+			t.Errorf("both enrollments should be saved, got rows %+v", repo.rows)
+		}
+	})
+
+	t.Run("failed rows are rolled back", func(t *testing.T) {
+		repo := &fakeEnrollmentRepo{failBatch: true, failInsert: true}
+		w := startTestWorker(t, repo, students, courses)
+
+		if err := w.Enroll(ctx, 1, 10); !errors.Is(err, e.ErrEnrollmentDBFailed) {
+			t.Fatalf("enroll: got %v, want %v", err, e.ErrEnrollmentDBFailed)
+		}
+		if w.cache.IsStudentEnrolled(1, 10) || w.cache.EnrolledCount[10].Load() != 0 {
+			t.Errorf("failed insert should leave the cache unchanged")
+		}
+	})
+}
+
+// latencyEnrollmentRepo adds a fixed round-trip time to every insert, like a remote DB
+type latencyEnrollmentRepo struct {
+	*fakeEnrollmentRepo
+	latency time.Duration
+}
+
+func (r *latencyEnrollmentRepo) InsertEnrollment(enrollment *models.Enrollment) error {
+	time.Sleep(r.latency)
+	return r.fakeEnrollmentRepo.InsertEnrollment(enrollment)
+}
+
+func (r *latencyEnrollmentRepo) BatchInsertEnrollments(enrollments []models.Enrollment) error {
+	time.Sleep(r.latency)
+	return r.fakeEnrollmentRepo.BatchInsertEnrollments(enrollments)
+}
+
+// BenchmarkEnrollSpike mimics the opening spike of test/performance/spike_test.py:
+// many students enroll at once, each in a different seat, against a DB with 1ms round trips.
+// batch_size=1 is the old one-insert-per-request worker.
+func BenchmarkEnrollSpike(b *testing.B) {
+	const numCourses = 100
+
+	for _, batchSize := range []int{1, maxBatchSize} {
+		b.Run(fmt.Sprintf("batch_size=%d", batchSize), func(b *testing.B) {
+			students := make([]models.Student, b.N)
+			for i := range students {
+				students[i].ID = uint(i + 1)
+			}
+			courses := make([]models.Course, numCourses)
+			for i := range courses {
+				courses[i] = models.Course{ID: uint(i + 1), Capacity: b.N, Schedules: fmt.Sprintf("월 %02d:00~%02d:30", i%20, i%20)}
+			}
+
+			repo := &latencyEnrollmentRepo{fakeEnrollmentRepo: &fakeEnrollmentRepo{}, latency: time.Millisecond}
+			w := NewEnrollmentWorker(b.N, 1, repo, nil, &testClock{})
+			w.batchSize = batchSize
+			if err := w.Start(cache.InitData{Students: students, Courses: courses}); err != nil {
+				b.Fatalf("start worker: %v", err)
+			}
+			defer w.Stop()
+
+			var next atomic.Int64
+			ctx := context.Background()
+			b.SetParallelism(100)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					i := next.Add(1)
+					if err := w.Enroll(ctx, uint(i), uint(i%numCourses+1)); err != nil {
+						b.Errorf("enroll %d: %v", i, err)
+					}
+				}
+			})
+		})
+	}
+}
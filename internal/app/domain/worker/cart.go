@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"fmt"
+	"slices"
+)
+
+// CourseResult is the outcome of one course in a cart enrollment; Err is nil on success
+type CourseResult struct {
+	CourseID uint
+	Err      error
+}
+
+// EnrollCart enrolls a student in several courses as one queue step.
+// With allOrNothing, either every course is enrolled or none is.
+// Otherwise every course that can be enrolled is, in cart order.
+// err is set when the request itself was not processed (busy queue, abandoned request); results are nil then.
+func (w *EnrollmentWorker) EnrollCart(ctx context.Context, studentID uint, courseIDs []uint, allOrNothing bool) ([]CourseResult, error) {
+	resp := w.submitRequest(ctx, EnrollmentRequest{
+		Type:         ENROLL_CART,
+		StudentID:    studentID,
+		CourseIDs:    courseIDs,
+		AllOrNothing: allOrNothing,
+	})
+	return resp.CartResults, resp.Err
+}
+
+// processEnrollCart decides each course against the cache, applying successes as it goes so later
+// courses are checked against earlier ones, then writes all new rows in one batch.
+// Co-requisites are taken with their course; one already taken that way succeeds again when its turn comes.
+// The cache changes are undone if the cart is rejected or the batch insert fails.
+func (w *EnrollmentWorker) processEnrollCart(req EnrollmentRequest) []CourseResult {
+	studentID := req.StudentID
+	results := make([]CourseResult, len(req.CourseIDs))
+	var rows []models.Enrollment
+	failed := false
+
+	for i, courseID := range req.CourseIDs {
+		results[i].CourseID = courseID
+
+		if slices.ContainsFunc(rows, func(row models.Enrollment) bool { return row.CourseID == courseID }) {
+			continue
+		}
+
+		taken, err := w.takeWithCorequisites(studentID, courseID, Overrides{})
+		if err != nil {
+			results[i].Err = err
+			failed = true
+			continue
+		}
+		rows = append(rows, taken...)
+	}
+
+	if len(rows) == 0 {
+		return results
+	}
+
+	var rejectErr error
+	if failed && req.AllOrNothing {
+		rejectErr = e.ErrCartRejected
+	} else if err := w.enrollRepo.BatchInsertEnrollments(rows); err != nil {
+		rejectErr = fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)
+	}
+
+	if rejectErr != nil {
+		w.undoTaken(studentID, rows)
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = rejectErr
+			}
+		}
+	}
+	return results
+}
@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func TestEnrollCart(t *testing.T) {
+	ctx := context.Background()
+	students := []models.Student{{ID: 1}, {ID: 2}}
+	courses := []models.Course{
+		{ID: 10, Capacity: 2, Schedules: "월 09:00~10:00"},
+		{ID: 20, Capacity: 2, Schedules: "월 09:30~10:30"}, // conflicts with 10
+		{ID: 30, Capacity: 2, Schedules: "화 09:00~10:00"},
+	}
+	cart := []uint{10, 20, 30}
+
+	t.Run("best effort", func(t *testing.T) {
+		repo := &fakeEnrollmentRepo{}
+		w := startTestWorker(t, repo, students, courses)
+
+		results, err := w.EnrollCart(ctx, 1, cart, false)
+		if err != nil {
+			t.Fatalf("enroll cart: %v", err)
+		}
+		wantErrs := []error{nil, e.ErrTimeConflict, nil}
+		for i, result := range results {
+			if result.CourseID != cart[i] || !errors.Is(result.Err, wantErrs[i]) {
+				t.Errorf("course %d: got %v, want %v", result.CourseID, result.Err, wantErrs[i])
+			}
+		}
+		if len(repo.rows) != 2 || !w.cache.IsStudentEnrolled(1, 10) || !w.cache.IsStudentEnrolled(1, 30) {
+			t.Errorf("courses 10 and 30 should be enrolled, rows: %v", repo.rows)
+		}
+	})
+
+	t.Run("all or nothing", func(t *testing.T) {
+		repo := &fakeEnrollmentRepo{}
+		w := startTestWorker(t, repo, students, courses)
+
+		results, err := w.EnrollCart(ctx, 1, cart, true)
+		if err != nil {
+			t.Fatalf("enroll cart: %v", err)
+		}
+		wantErrs := []error{e.ErrCartRejected, e.ErrTimeConflict, e.ErrCartRejected}
+		for i, result := range results {
+			if !errors.Is(result.Err, wantErrs[i]) {
+				t.Errorf("course %d: got %v, want %v", result.CourseID, result.Err, wantErrs[i])
+			}
+		}
+		if len(repo.rows) != 0 || len(w.cache.StudentCourses[1]) != 0 || w.cache.EnrolledCount[10].Load() != 0 {
+			t.Errorf("rejected cart should leave no enrollments")
+		}
+
+		if results, err := w.EnrollCart(ctx, 1, []uint{10, 30}, true); err != nil || results[0].Err != nil || results[1].Err != nil {
+			t.Errorf("cart without conflicts: got %v, %v", results, err)
+		}
+	})
+
+	t.Run("batch failure rolls back cache", func(t *testing.T) {
+		repo := &fakeEnrollmentRepo{failBatch: true}
+		w := startTestWorker(t, repo, students, courses)
+
+		results, err := w.EnrollCart(ctx, 2, []uint{10, 30}, false)
+		if err != nil {
+			t.Fatalf("enroll cart: %v", err)
+		}
+		for _, result := range results {
+			if !errors.Is(result.Err, e.ErrEnrollmentDBFailed) {
+				t.Errorf("course %d: got %v, want %v", result.CourseID, result.Err, e.ErrEnrollmentDBFailed)
+			}
+		}
+		if len(w.cache.StudentCourses[2]) != 0 || w.cache.EnrolledCount[10].Load() != 0 {
+			t.Errorf("failed batch should leave the cache unchanged")
+		}
+	})
+
+	t.Run("abandoned request is an error", func(t *testing.T) {
+		repo := &fakeEnrollmentRepo{}
+		w := startTestWorker(t, repo, students, courses)
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		results, err := w.EnrollCart(cancelled, 1, []uint{10, 30}, false)
+		if !errors.Is(err, e.ErrRequestAbandoned) || results != nil {
+			t.Errorf("got %v, %v, want %v", results, err, e.ErrRequestAbandoned)
+		}
+		if len(repo.rows) != 0 {
+			t.Errorf("abandoned cart should not enroll, rows: %v", repo.rows)
+		}
+	})
+}
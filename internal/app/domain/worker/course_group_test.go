@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func TestCourseGroups(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	w := NewEnrollmentWorker(10, 1, repo, nil, &testClock{})
+	err := w.Start(cache.InitData{
+		Students: []models.Student{{ID: 1}, {ID: 2}, {ID: 3}},
+		Courses: []models.Course{
+			{ID: 10, Capacity: 5, Schedules: "월 09:00~10:00"}, // beginner
+			{ID: 11, Capacity: 5, Schedules: "화 09:00~10:00"}, // advanced
+			{ID: 20, Capacity: 5, Schedules: "수 09:00~10:00"}, // lecture
+			{ID: 21, Capacity: 1, Schedules: "목 09:00~10:00"}, // lab
+		},
+		CourseGroups: []models.CourseGroup{
+			{ID: 1, Type: cache.GroupExclusive, CourseIDs: []uint{10, 11}},
+			{ID: 2, Type: cache.GroupCorequisite, CourseIDs: []uint{20, 21}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+
+	// Exclusive group
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.Enroll(ctx, 1, 11); !errors.Is(err, e.ErrExclusiveCourseConflict) {
+		t.Errorf("second course of the group: got %v, want %v", err, e.ErrExclusiveCourseConflict)
+	}
+	if err := w.Swap(ctx, 1, 10, 11); err != nil {
+		t.Errorf("swap within the group: %v", err)
+	}
+	if err := w.AdminEnroll(ctx, 1, 10, Overrides{IgnoreCourseGroups: true}); err != nil {
+		t.Errorf("admin enroll ignoring course groups: %v", err)
+	}
+
+	// Co-requisites are enrolled and cancelled together
+	if err := w.Enroll(ctx, 2, 21); err != nil {
+		t.Fatalf("enroll lab: %v", err)
+	}
+	if !w.cache.IsStudentEnrolled(2, 20) {
+		t.Errorf("lecture was not enrolled with the lab")
+	}
+	if err := w.Enroll(ctx, 3, 20); !errors.Is(err, e.ErrCorequisiteFailed) || !errors.Is(err, e.ErrCourseFull) {
+		t.Errorf("lab full: got %v, want %v and %v", err, e.ErrCorequisiteFailed, e.ErrCourseFull)
+	}
+	if w.cache.IsStudentEnrolled(3, 20) {
+		t.Errorf("lecture kept although the lab failed")
+	}
+	if _, err := w.JoinWaitlist(ctx, 3, 21); !errors.Is(err, e.ErrCorequisiteUnsupported) {
+		t.Errorf("waitlist: got %v, want %v", err, e.ErrCorequisiteUnsupported)
+	}
+
+	if err := w.Cancel(ctx, 2, 20); err != nil {
+		t.Fatalf("cancel lecture: %v", err)
+	}
+	if w.cache.IsStudentEnrolled(2, 21) {
+		t.Errorf("lab kept after cancelling the lecture")
+	}
+	for _, row := range repo.rows {
+		if row.StudentID == 2 {
+			t.Errorf("row left for student 2: %+v", row)
+		}
+	}
+
+	// The cart takes the pair once, even when both are in it
+	results, err := w.EnrollCart(ctx, 3, []uint{20, 21}, true)
+	if err != nil {
+		t.Fatalf("enroll cart: %v", err)
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("cart course %d: %v", r.CourseID, r.Err)
+		}
+	}
+	if got := w.cache.EnrolledCount[21].Load(); got != 1 {
+		t.Errorf("lab enrolled count: got %d, want 1", got)
+	}
+}
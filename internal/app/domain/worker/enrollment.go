@@ -1,105 +1,422 @@
 package worker
 
 import (
+	"context"
 	"course-reg/internal/app/domain/cache"
 	"course-reg/internal/app/domain/constants"
 	"course-reg/internal/app/domain/e"
 	"course-reg/internal/app/models"
 	"errors"
 	"fmt"
+	"log"
+	"time"
 )
 
 // EnrollmentRequest represents an enrollment request
 type EnrollmentRequest struct {
-	Type      RequestType
-	StudentID uint
-	CourseID  uint
-	Response  chan error
+	Type         RequestType
+	StudentID    uint
+	CourseID     uint
+	CourseIDs    []uint          // set only for cart enrollments
+	AllOrNothing bool            // set only for cart enrollments
+	ToCourseID   uint            // set only for swaps; CourseID is the course being dropped
+	Overrides    Overrides       // set only for admin enrollments
+	Limit        *cache.Limit    // set only for limit changes; nil removes the student's override
+	Ctx          context.Context // the worker skips requests whose caller has already given up
+	Response     chan EnrollmentResponse
 }
 
-func (w *EnrollmentWorker) Start(students []models.Student, courses []models.Course, enrollments []models.Enrollment) error {
-	if w.requestChan != nil {
+// EnrollmentResponse represents the result of an enrollment request
+type EnrollmentResponse struct {
+	Err              error
+	WaitlistPosition int            // 1-based, set only for waitlist joins
+	CartResults      []CourseResult // set only for cart enrollments
+	HoldExpiresAt    time.Time      // set only for seat holds
+}
+
+func (w *EnrollmentWorker) Start(data cache.InitData) error {
+	if w.requestChans != nil {
 		return errors.New("worker already running")
 	}
 
-	enrollmentCache, err := cache.NewEnrollmentCacheWithData(students, courses, enrollments)
+	if w.journal != nil {
+		data.Enrollments = withJournaled(data.Enrollments, w.journal.Pending(), data.TermID)
+	}
+	enrollmentCache, err := cache.NewEnrollmentCache(data)
 	if err != nil {
 		return err
 	}
 
-	w.requestChan = make(chan EnrollmentRequest, w.queueSize)
+	w.requestChans = make([]chan EnrollmentRequest, w.shards)
+	for i := range w.requestChans {
+		w.requestChans[i] = make(chan EnrollmentRequest, max(w.queueSize/w.shards, 1))
+	}
 	w.cache = enrollmentCache
 
-	w.wg.Add(1)
-	go func() {
-		defer w.wg.Done()
-		w.worker()
-	}()
+	for i, requestChan := range w.requestChans {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			w.worker(requestChan, i == 0)
+		}()
+	}
+
+	if w.journal != nil {
+		w.stopFlusher = make(chan struct{})
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			w.flusher(w.stopFlusher)
+		}()
+	}
 
 	return nil
 }
 
+// Stop waits for the queued requests and, with a journal, tries once more to apply it.
+// Rows that still cannot be applied stay journaled and are loaded again on the next Start.
 func (w *EnrollmentWorker) Stop() {
-	close(w.requestChan)
+	for _, requestChan := range w.requestChans {
+		close(requestChan)
+	}
+	if w.stopFlusher != nil {
+		close(w.stopFlusher)
+	}
 	w.wg.Wait()
-	w.requestChan = nil
+	w.requestChans = nil
+	w.stopFlusher = nil
+
+	if w.journal != nil {
+		if err := w.flushJournal(); err != nil {
+			log.Printf("[warn] flush enrollment journal on stop failed, rows stay journaled: %v", err)
+		}
+	}
 }
 
-func (w *EnrollmentWorker) worker() {
-	for req := range w.requestChan {
-		var err error
+// worker runs one shard; sweepHolds is set for the one shard that releases expired holds
+func (w *EnrollmentWorker) worker(requestChan chan EnrollmentRequest, sweepHolds bool) {
+	var sweep <-chan time.Time
+	if sweepHolds {
+		ticker := time.NewTicker(holdSweepInterval)
+		defer ticker.Stop()
+		sweep = ticker.C
+	}
 
-		switch req.Type {
-		case ENROLL:
-			err = w.processEnroll(req)
+	for {
+		select {
+		case req, ok := <-requestChan:
+			if !ok {
+				return
+			}
+			w.processBatch(w.drain(requestChan, req))
+		case <-sweep:
+			w.mu.Lock()
+			w.releaseExpiredHolds()
+			w.mu.Unlock()
 		}
+	}
+}
 
-		req.Response <- err
+// process handles one request against the cache
+func (w *EnrollmentWorker) process(req EnrollmentRequest) EnrollmentResponse {
+	var resp EnrollmentResponse
+
+	switch req.Type {
+	case ENROLL:
+		resp.Err = w.processEnroll(req)
+	case CANCEL:
+		resp.Err = w.processCancel(req)
+	case JOIN_WAITLIST:
+		resp.WaitlistPosition, resp.Err = w.processJoinWaitlist(req)
+	case LEAVE_WAITLIST:
+		resp.Err = w.processLeaveWaitlist(req)
+	case ENROLL_CART:
+		resp.CartResults = w.processEnrollCart(req)
+	case SWAP:
+		resp.Err = w.processSwap(req)
+	case ADMIN_ENROLL:
+		resp.Err = w.processAdminEnroll(req)
+	case ADMIN_CANCEL:
+		resp.Err = w.processCancel(req)
+	case HOLD:
+		resp.HoldExpiresAt, resp.Err = w.processHold(req)
+	case CONFIRM_HOLD:
+		resp.Err = w.processConfirmHold(req)
+	case RELEASE_HOLD:
+		resp.Err = w.processReleaseHold(req)
+	case RELEASE_EXPIRED_HOLDS:
+		w.releaseExpiredHolds()
+	case SET_LIMIT:
+		w.cache.SetStudentLimit(req.StudentID, req.Limit)
 	}
+
+	return resp
+}
+
+func (w *EnrollmentWorker) Enroll(ctx context.Context, studentID, courseID uint) error {
+	return w.submit(ctx, ENROLL, studentID, courseID).Err
+}
+
+func (w *EnrollmentWorker) Cancel(ctx context.Context, studentID, courseID uint) error {
+	return w.submit(ctx, CANCEL, studentID, courseID).Err
+}
+
+// JoinWaitlist puts a student on a full course's waitlist and returns their position
+func (w *EnrollmentWorker) JoinWaitlist(ctx context.Context, studentID, courseID uint) (int, error) {
+	resp := w.submit(ctx, JOIN_WAITLIST, studentID, courseID)
+	return resp.WaitlistPosition, resp.Err
+}
+
+func (w *EnrollmentWorker) LeaveWaitlist(ctx context.Context, studentID, courseID uint) error {
+	return w.submit(ctx, LEAVE_WAITLIST, studentID, courseID).Err
 }
 
-func (w *EnrollmentWorker) Enroll(studentID, courseID uint) error {
-	req := EnrollmentRequest{
-		Type:      ENROLL,
+// submit enqueues a request to the worker and waits for its result
+func (w *EnrollmentWorker) submit(ctx context.Context, reqType RequestType, studentID, courseID uint) EnrollmentResponse {
+	return w.submitRequest(ctx, EnrollmentRequest{
+		Type:      reqType,
 		StudentID: studentID,
 		CourseID:  courseID,
-		Response:  make(chan error, 1),
+	})
+}
+
+// submitRequest enqueues a request without blocking and waits for its result until ctx is done.
+// A full queue fails fast with ErrServerBusy instead of piling up callers.
+// A request abandoned while queued is skipped by the worker; one abandoned while being processed
+// still completes, so ErrRequestAbandoned means the outcome is unknown to the caller.
+func (w *EnrollmentWorker) submitRequest(ctx context.Context, req EnrollmentRequest) EnrollmentResponse {
+	response, err := w.enqueue(ctx, req)
+	if err != nil {
+		return EnrollmentResponse{Err: err}
+	}
+
+	select {
+	case resp := <-response:
+		return resp
+	case <-ctx.Done():
+		return EnrollmentResponse{Err: fmt.Errorf("%w: %w", e.ErrRequestAbandoned, ctx.Err())}
 	}
+}
 
-	w.requestChan <- req
-	return <-req.Response
+// Submit enqueues a request like submitRequest but does not wait for it.
+// wait blocks until the worker answers, which it does for every accepted request, even one stopped with the worker.
+// Callers that must learn the outcome after giving up pass a ctx that is never cancelled.
+func (w *EnrollmentWorker) Submit(ctx context.Context, req EnrollmentRequest) (wait func() error, err error) {
+	response, err := w.enqueue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return func() error { return (<-response).Err }, nil
+}
+
+// enqueue puts req on its student's shard without blocking and returns the channel its response arrives on
+func (w *EnrollmentWorker) enqueue(ctx context.Context, req EnrollmentRequest) (<-chan EnrollmentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", e.ErrRequestAbandoned, err)
+	}
+
+	req.Ctx = ctx
+	req.Response = make(chan EnrollmentResponse, 1)
+	select {
+	case w.requestChans[req.StudentID%uint(len(w.requestChans))] <- req:
+		return req.Response, nil
+	default:
+		return nil, e.ErrServerBusy
+	}
 }
 
 // processEnroll handles enrollment logic
+// Enrolling in a course the student holds a seat in confirms the hold.
 func (w *EnrollmentWorker) processEnroll(req EnrollmentRequest) error {
-	studentID := req.StudentID
-	courseID := req.CourseID
+	if _, ok := w.cache.HoldExpiry(req.StudentID, req.CourseID); ok {
+		return w.confirmHold(req.StudentID, req.CourseID, Overrides{})
+	}
+	return w.enroll(req.StudentID, req.CourseID, Overrides{})
+}
+
+// enroll takes a seat in the course, skipping the rules set in overrides.
+// Co-requisites the student is not enrolled in yet are enrolled together with it, or not at all.
+func (w *EnrollmentWorker) enroll(studentID, courseID uint, overrides Overrides) error {
+	pos, err := w.checkEnroll(studentID, courseID, overrides)
+	if err != nil {
+		return err
+	}
+
+	if !overrides.IgnoreCourseGroups && len(w.cache.MissingCorequisites(studentID, courseID)) > 0 {
+		rows, err := w.takeWithCorequisites(studentID, courseID, overrides)
+		if err != nil {
+			return err
+		}
+		if err := w.enrollRepo.BatchInsertEnrollments(rows); err != nil {
+			w.undoTaken(studentID, rows)
+			return fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)
+		}
+		return nil
+	}
+
+	// A free seat with a waiting student left means promotion skipped them; take the seat from the waitlist
+	if w.cache.IsStudentWaiting(studentID, courseID) {
+		return w.promoteStudent(studentID, courseID, pos)
+	}
+
+	if err := w.enrollRepo.InsertEnrollment(&models.Enrollment{TermID: w.cache.TermID, StudentID: studentID, CourseID: courseID, Position: pos}); err != nil {
+		return fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)
+	}
+	w.cache.EnrollStudent(studentID, courseID)
+
+	return nil
+}
 
+// checkEnroll checks if the student can take a seat in the course, skipping the rules set in overrides,
+// and returns the seat position. A seat taken over capacity gets the next position after the last enrolled student.
+func (w *EnrollmentWorker) checkEnroll(studentID, courseID uint, overrides Overrides) (int, error) {
 	if !w.cache.CourseExists(courseID) {
-		return e.ErrCourseNotFound
+		return 0, e.ErrCourseNotFound
 	}
 
 	if !w.cache.StudentExists(studentID) {
-		return e.ErrStudentNotFound
+		return 0, e.ErrStudentNotFound
 	}
 
-	if w.cache.HasTimeConflict(studentID, courseID) {
-		return e.ErrTimeConflict
+	if !overrides.IgnoreTimeConflict && w.cache.HasTimeConflict(studentID, courseID) {
+		return 0, e.ErrTimeConflict
 	}
 
 	if w.cache.IsStudentEnrolled(studentID, courseID) {
-		return e.ErrAlreadyEnrolled
+		return 0, e.ErrAlreadyEnrolled
+	}
+
+	if !overrides.IgnoreCourseGroups && w.cache.HasExclusiveConflict(studentID, courseID) {
+		return 0, e.ErrExclusiveCourseConflict
+	}
+
+	if !overrides.BypassEligibility {
+		if err := w.checkPrerequisites(studentID, courseID); err != nil {
+			return 0, err
+		}
+		if err := w.cache.CheckEligibility(studentID, courseID); err != nil {
+			return 0, err
+		}
+	}
+
+	if !overrides.IgnoreLimits {
+		if err := w.checkLimits(studentID, courseID); err != nil {
+			return 0, err
+		}
 	}
 
 	pos, err := w.cache.GetPosIfNotFull(courseID)
 	if err != nil {
-		return e.ErrCourseFull
+		if !overrides.IgnoreCapacity {
+			return 0, e.ErrCourseFull
+		}
+		pos = int(w.cache.EnrolledCount[courseID].Load())
+	}
+	return pos, nil
+}
+
+// takeWithCorequisites checks the course and its missing co-requisites in course order, each against the ones
+// before it, and applies them to the cache. Nothing is applied if any of them fails.
+// Returns the rows to insert; the caller must undo them with undoTaken if the insert fails.
+func (w *EnrollmentWorker) takeWithCorequisites(studentID, courseID uint, overrides Overrides) ([]models.Enrollment, error) {
+	var courseIDs []uint
+	if w.cache.CourseExists(courseID) && w.cache.StudentExists(studentID) && !overrides.IgnoreCourseGroups {
+		courseIDs = w.cache.MissingCorequisites(studentID, courseID)
+	}
+	courseIDs = append([]uint{courseID}, courseIDs...)
+
+	var rows []models.Enrollment
+	for _, id := range courseIDs {
+		pos, err := w.checkEnroll(studentID, id, overrides)
+		if err == nil && w.cache.IsStudentWaiting(studentID, id) {
+			// Taking a seat from the waitlist updates an existing row and cannot be batched
+			err = e.ErrAlreadyWaitlisted
+		}
+		if _, held := w.cache.HoldExpiry(studentID, id); err == nil && held {
+			// A held seat is taken by confirming the hold
+			err = e.ErrSeatAlreadyHeld
+		}
+		if err != nil {
+			w.undoTaken(studentID, rows)
+			if id != courseID {
+				return nil, fmt.Errorf("%w (course %d): %w", e.ErrCorequisiteFailed, id, err)
+			}
+			return nil, err
+		}
+
+		w.cache.EnrollStudent(studentID, id)
+		rows = append(rows, models.Enrollment{TermID: w.cache.TermID, StudentID: studentID, CourseID: id, Position: pos})
+	}
+	return rows, nil
+}
+
+// undoTaken removes the cache changes of takeWithCorequisites
+func (w *EnrollmentWorker) undoTaken(studentID uint, rows []models.Enrollment) {
+	for _, row := range rows {
+		w.cache.CancelStudent(studentID, row.CourseID)
+	}
+}
+
+// checkPrerequisites checks if the student completed every prerequisite of the course in a previous term
+func (w *EnrollmentWorker) checkPrerequisites(studentID, courseID uint) error {
+	if code, missing := w.cache.MissingPrerequisite(studentID, courseID); missing {
+		return fmt.Errorf("%w: course %s", e.ErrPrerequisiteNotMet, code)
+	}
+	return nil
+}
+
+// checkLimits checks the student's course, credit and special course limits for taking courseID,
+// not counting the courses in except
+func (w *EnrollmentWorker) checkLimits(studentID, courseID uint, except ...uint) error {
+	if w.cache.ExceedsCourseLimit(studentID, except...) {
+		return e.ErrCourseLimitExceeded
+	}
+	if w.cache.ExceedsCreditLimit(studentID, courseID, except...) {
+		return e.ErrCreditLimitExceeded
 	}
+	if w.cache.ExceedsSpecialLimit(studentID, courseID, except...) {
+		return e.ErrSpecialLimitExceeded
+	}
+	return nil
+}
 
-	if err := w.enrollRepo.InsertEnrollment(&models.Enrollment{StudentID: studentID, CourseID: courseID, Position: pos}); err != nil {
+// processCancel handles enrollment cancellation logic.
+// Co-requisites of the course are cancelled with it, so lecture and lab are never left half taken.
+func (w *EnrollmentWorker) processCancel(req EnrollmentRequest) error {
+	studentID := req.StudentID
+	courseID := req.CourseID
+
+	if !w.cache.CourseExists(courseID) {
+		return e.ErrCourseNotFound
+	}
+
+	if !w.cache.StudentExists(studentID) {
+		return e.ErrStudentNotFound
+	}
+
+	if !w.cache.IsStudentEnrolled(studentID, courseID) {
+		return e.ErrNotEnrolled
+	}
+
+	courseIDs := append([]uint{courseID}, w.cache.EnrolledCorequisites(studentID, courseID)...)
+	if len(courseIDs) == 1 {
+		if err := w.enrollRepo.DeleteEnrollment(studentID, courseID); err != nil {
+			return fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)
+		}
+	} else if err := w.enrollRepo.DeleteEnrollments(studentID, courseIDs); err != nil {
 		return fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)
 	}
-	w.cache.EnrollStudent(studentID, courseID)
+	for _, id := range courseIDs {
+		w.cache.CancelStudent(studentID, id)
+	}
+
+	// The freed seats go to the waitlist, and the cancelled courses may no longer block this student's own waitlists
+	for _, id := range courseIDs {
+		w.promoteWaitlist(id)
+	}
+	for waitingCourseID := range w.cache.StudentWaitingCourses[studentID] {
+		w.promoteWaitlist(waitingCourseID)
+	}
 
 	return nil
 }
@@ -108,7 +425,7 @@ func (w *EnrollmentWorker) processEnroll(req EnrollmentRequest) error {
 func (w *EnrollmentWorker) GetAllCourseStatus() map[uint]constants.CourseStatus {
 	status := make(map[uint]constants.CourseStatus)
 	for courseID, info := range w.cache.GetAllCourseCountInfo() {
-		if info.EnrolledCount < info.Capacity {
+		if info.EnrolledCount+info.HeldCount < info.Capacity {
 			status[courseID] = constants.CourseAvailable
 		} else if info.WaitingCount < info.Capacity {
 			status[courseID] = constants.CourseWaitlist
@@ -118,35 +435,3 @@ func (w *EnrollmentWorker) GetAllCourseStatus() map[uint]constants.CourseStatus
 	}
 	return status
 }
-
-func (w *EnrollmentWorker) processAddWaitList() {
-	// isWaitlistFull, err := w.cache.IsWaitlistFull(courseID)
-	// if err != nil {
-	// 	return EnrollmentResponse{
-	// 		Success:        false,
-	// 		Message:        "강의 정보를 찾을 수 없습니다",
-	// 	}
-	// }
-
-	// if !isWaitlistFull {
-	// 	position, err := w.cache.AddToWaitlist(studentID, courseID)
-	// 	if err != nil {
-	// 		return EnrollmentResponse{
-	// 			Success:        false,
-	// 			Message:        "존재하지 않는 학생입니다",
-	// 		}
-	// 	}
-	// 	return EnrollmentResponse{
-	// 		Success:          false,
-	// 		Message:          "대기열에 등록되었습니다",
-	// 		WaitlistPosition: position,
-	// 	}
-	// }
-
-	// // Both course and waitlist are full
-	// return EnrollmentResponse{
-	// 	Success:        false,
-	// 	Message:        "대기열이 마감되었습니다",
-	// }
-
-}
@@ -0,0 +1,375 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+// fakeEnrollmentRepo is an in-memory EnrollmentRepositoryInterface
+type fakeEnrollmentRepo struct {
+	rows       []models.Enrollment
+	batchSizes []int // sizes of the successful batch inserts
+	failBatch  bool
+	failInsert bool
+}
+
+func (r *fakeEnrollmentRepo) find(studentID, courseID uint, isWaitlist bool) int {
+	for i, row := range r.rows {
+		if row.StudentID == studentID && row.CourseID == courseID && row.IsWaitlist == isWaitlist {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *fakeEnrollmentRepo) shiftWaitlist(courseID uint, after int) {
+	for i := range r.rows {
+		if r.rows[i].CourseID == courseID && r.rows[i].IsWaitlist && r.rows[i].Position > after {
+			r.rows[i].Position--
+		}
+	}
+}
+
+func (r *fakeEnrollmentRepo) InsertEnrollment(enrollment *models.Enrollment) error {
+	if r.failInsert {
+		return errors.New("insert failed")
+	}
+	r.rows = append(r.rows, *enrollment)
+	return nil
+}
+
+func (r *fakeEnrollmentRepo) BatchInsertEnrollments(enrollments []models.Enrollment) error {
+	if r.failBatch {
+		return errors.New("batch insert failed")
+	}
+	r.rows = append(r.rows, enrollments...)
+	r.batchSizes = append(r.batchSizes, len(enrollments))
+	return nil
+}
+
+func (r *fakeEnrollmentRepo) InsertEnrollmentsIfAbsent(enrollments []models.Enrollment) error {
+	if r.failInsert {
+		return errors.New("insert failed")
+	}
+	// Same unique index as the enrollments table: (student_id, course_id, position)
+	for _, row := range enrollments {
+		exists := slices.ContainsFunc(r.rows, func(stored models.Enrollment) bool {
+			return stored.StudentID == row.StudentID && stored.CourseID == row.CourseID && stored.Position == row.Position
+		})
+		if !exists {
+			r.rows = append(r.rows, row)
+		}
+	}
+	return nil
+}
+
+func (r *fakeEnrollmentRepo) DeleteEnrollment(studentID uint, courseID uint) error {
+	i := r.find(studentID, courseID, false)
+	if i < 0 {
+		return errors.New("enrollment not found")
+	}
+	r.rows = append(r.rows[:i], r.rows[i+1:]...)
+	return nil
+}
+
+func (r *fakeEnrollmentRepo) DeleteEnrollments(studentID uint, courseIDs []uint) error {
+	for _, courseID := range courseIDs {
+		if r.find(studentID, courseID, false) < 0 {
+			return errors.New("enrollment not found")
+		}
+	}
+	for _, courseID := range courseIDs {
+		i := r.find(studentID, courseID, false)
+		r.rows = append(r.rows[:i], r.rows[i+1:]...)
+	}
+	return nil
+}
+
+func (r *fakeEnrollmentRepo) DeleteWaitlistEntry(studentID uint, courseID uint) error {
+	i := r.find(studentID, courseID, true)
+	if i < 0 {
+		return errors.New("waitlist entry not found")
+	}
+	pos := r.rows[i].Position
+	r.rows = append(r.rows[:i], r.rows[i+1:]...)
+	r.shiftWaitlist(courseID, pos)
+	return nil
+}
+
+func (r *fakeEnrollmentRepo) PromoteWaitlistEntry(studentID uint, courseID uint, position int) error {
+	i := r.find(studentID, courseID, true)
+	if i < 0 {
+		return errors.New("waitlist entry not found")
+	}
+	pos := r.rows[i].Position
+	r.rows[i].IsWaitlist = false
+	r.rows[i].Position = position
+	r.shiftWaitlist(courseID, pos)
+	return nil
+}
+
+func (r *fakeEnrollmentRepo) SwapEnrollment(studentID uint, fromCourseID uint, toCourseID uint, position int) error {
+	if err := r.DeleteEnrollment(studentID, fromCourseID); err != nil {
+		return err
+	}
+	if r.find(studentID, toCourseID, true) >= 0 {
+		return r.PromoteWaitlistEntry(studentID, toCourseID, position)
+	}
+	return r.InsertEnrollment(&models.Enrollment{StudentID: studentID, CourseID: toCourseID, Position: position})
+}
+
+func (r *fakeEnrollmentRepo) FetchAllEnrollments(termID uint) ([]models.Enrollment, error) {
+	var rows []models.Enrollment
+	for _, row := range r.rows {
+		if row.TermID == termID {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (r *fakeEnrollmentRepo) DeleteAllEnrollments(termID uint) error {
+	r.rows = slices.DeleteFunc(r.rows, func(row models.Enrollment) bool { return row.TermID == termID })
+	return nil
+}
+
+// testClock is a settable clock, safe to read from the worker goroutine
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func startTestWorker(t *testing.T, repo *fakeEnrollmentRepo, students []models.Student, courses []models.Course) *EnrollmentWorker {
+	t.Helper()
+	w := NewEnrollmentWorker(10, 1, repo, nil, &testClock{})
+	if err := w.Start(cache.InitData{Students: students, Courses: courses, Enrollments: repo.rows}); err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+	return w
+}
+
+func TestCancelPromotesWaitlist(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	students := []models.Student{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}
+	courses := []models.Course{
+		{ID: 10, Capacity: 2, Schedules: "월 09:00~10:00"},
+		{ID: 20, Capacity: 1, Schedules: "월 09:30~10:30"}, // conflicts with 10
+	}
+	w := startTestWorker(t, repo, students, courses)
+
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.Enroll(ctx, 5, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.Enroll(ctx, 2, 20); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if _, err := w.JoinWaitlist(ctx, 2, 10); !errors.Is(err, e.ErrTimeConflict) {
+		t.Fatalf("join waitlist with conflict: got %v, want %v", err, e.ErrTimeConflict)
+	}
+	if pos, err := w.JoinWaitlist(ctx, 3, 10); err != nil || pos != 1 {
+		t.Fatalf("join waitlist: got (%d, %v), want (1, nil)", pos, err)
+	}
+	if pos, err := w.JoinWaitlist(ctx, 4, 10); err != nil || pos != 2 {
+		t.Fatalf("join waitlist: got (%d, %v), want (2, nil)", pos, err)
+	}
+
+	if err := w.Cancel(ctx, 1, 10); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+
+	if !w.cache.IsStudentEnrolled(3, 10) {
+		t.Errorf("student 3 should have been promoted")
+	}
+	if got := w.cache.CourseWaitlist[10]; len(got) != 1 || got[0] != 4 {
+		t.Errorf("remaining waitlist: got %v, want [4]", got)
+	}
+	if i := repo.find(4, 10, true); i < 0 || repo.rows[i].Position != 0 {
+		t.Errorf("remaining waitlist row should have moved to position 0")
+	}
+}
+
+func TestPromotionSkipsConflictingStudent(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	students := []models.Student{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	courses := []models.Course{
+		{ID: 10, Capacity: 2, Schedules: "월 09:00~10:00"},
+		{ID: 20, Capacity: 1, Schedules: "월 09:30~10:30"}, // conflicts with 10
+	}
+	w := startTestWorker(t, repo, students, courses)
+
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.Enroll(ctx, 4, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if _, err := w.JoinWaitlist(ctx, 2, 10); err != nil {
+		t.Fatalf("join waitlist: %v", err)
+	}
+	if _, err := w.JoinWaitlist(ctx, 3, 10); err != nil {
+		t.Fatalf("join waitlist: %v", err)
+	}
+	// student 2 picks up a conflicting course while waiting
+	if err := w.Enroll(ctx, 2, 20); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+
+	if err := w.Cancel(ctx, 1, 10); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+
+	if !w.cache.IsStudentEnrolled(3, 10) {
+		t.Errorf("student 3 should have been promoted past the conflicting student 2")
+	}
+	if !w.cache.IsStudentWaiting(2, 10) {
+		t.Errorf("student 2 should keep their place on the waitlist")
+	}
+}
+
+func TestEnrollmentRowsBelongToCacheTerm(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	w := NewEnrollmentWorker(10, 1, repo, nil, &testClock{})
+	data := cache.InitData{
+		TermID:   3,
+		Students: []models.Student{{ID: 1}, {ID: 2}},
+		Courses:  []models.Course{{ID: 10, TermID: 3, Capacity: 1, Schedules: "월 09:00~10:00"}},
+	}
+	if err := w.Start(data); err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if _, err := w.JoinWaitlist(ctx, 2, 10); err != nil {
+		t.Fatalf("join waitlist: %v", err)
+	}
+
+	rows, _ := repo.FetchAllEnrollments(3)
+	if len(rows) != 2 {
+		t.Errorf("term 3 rows: got %d, want 2 (all rows: %+v)", len(rows), repo.rows)
+	}
+}
+
+// blockingEnrollmentRepo holds the worker inside the first insert until release is closed
+type blockingEnrollmentRepo struct {
+	*fakeEnrollmentRepo
+	entered chan struct{}
+	release chan struct{}
+}
+
+func newBlockingEnrollmentRepo() *blockingEnrollmentRepo {
+	return &blockingEnrollmentRepo{
+		fakeEnrollmentRepo: &fakeEnrollmentRepo{},
+		entered:            make(chan struct{}, 1),
+		release:            make(chan struct{}),
+	}
+}
+
+func (r *blockingEnrollmentRepo) wait() {
+	select {
+	case r.entered <- struct{}{}:
+	default:
+	}
+	<-r.release
+}
+
+func (r *blockingEnrollmentRepo) InsertEnrollment(enrollment *models.Enrollment) error {
+	r.wait()
+	return r.fakeEnrollmentRepo.InsertEnrollment(enrollment)
+}
+
+func (r *blockingEnrollmentRepo) BatchInsertEnrollments(enrollments []models.Enrollment) error {
+	r.wait()
+	return r.fakeEnrollmentRepo.BatchInsertEnrollments(enrollments)
+}
+
+// waitQueued waits until n requests are waiting in the worker's queues
+func waitQueued(w *EnrollmentWorker, n int) {
+	for {
+		queued := 0
+		for _, requestChan := range w.requestChans {
+			queued += len(requestChan)
+		}
+		if queued >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBackpressureAndAbandonedRequests(t *testing.T) {
+	ctx := context.Background()
+	repo := newBlockingEnrollmentRepo()
+	w := NewEnrollmentWorker(1, 1, repo, nil, &testClock{})
+	data := cache.InitData{
+		Students: []models.Student{{ID: 1}, {ID: 2}, {ID: 3}},
+		Courses: []models.Course{
+			{ID: 10, Capacity: 5, Schedules: "월 09:00~10:00"},
+			{ID: 20, Capacity: 5, Schedules: "화 09:00~10:00"},
+		},
+	}
+	if err := w.Start(data); err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+
+	// Student 1 occupies the worker
+	first := make(chan error, 1)
+	go func() { first <- w.Enroll(ctx, 1, 10) }()
+	<-repo.entered
+
+	// Student 2 fills the queue and then gives up
+	abandonCtx, abandon := context.WithCancel(ctx)
+	second := make(chan error, 1)
+	go func() { second <- w.Enroll(abandonCtx, 2, 10) }()
+	waitQueued(w, 1)
+
+	if err := w.Enroll(ctx, 3, 20); !errors.Is(err, e.ErrServerBusy) {
+		t.Errorf("enroll with a full queue: got %v, want %v", err, e.ErrServerBusy)
+	}
+
+	abandon()
+	if err := <-second; !errors.Is(err, e.ErrRequestAbandoned) || !errors.Is(err, context.Canceled) {
+		t.Errorf("abandoned enroll: got %v, want %v", err, e.ErrRequestAbandoned)
+	}
+
+	close(repo.release)
+	if err := <-first; err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.Enroll(ctx, 3, 20); err != nil {
+		t.Fatalf("enroll after the queue drained: %v", err)
+	}
+	if w.cache.IsStudentEnrolled(2, 10) || repo.find(2, 10, false) >= 0 {
+		t.Errorf("an abandoned request must not be processed")
+	}
+}
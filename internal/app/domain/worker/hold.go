@@ -0,0 +1,151 @@
+package worker
+
+import (
+	"context"
+	"course-reg/internal/app/domain/e"
+	"log"
+	"time"
+)
+
+// HoldSeat reserves a seat for HoldTTL and returns when the hold expires.
+// The seat counts against capacity until it is confirmed, released or expires.
+func (w *EnrollmentWorker) HoldSeat(ctx context.Context, studentID, courseID uint) (time.Time, error) {
+	resp := w.submit(ctx, HOLD, studentID, courseID)
+	return resp.HoldExpiresAt, resp.Err
+}
+
+// ConfirmHold turns a held seat into an enrollment
+func (w *EnrollmentWorker) ConfirmHold(ctx context.Context, studentID, courseID uint) error {
+	return w.submit(ctx, CONFIRM_HOLD, studentID, courseID).Err
+}
+
+// ReleaseHold gives a held seat back before it expires
+func (w *EnrollmentWorker) ReleaseHold(ctx context.Context, studentID, courseID uint) error {
+	return w.submit(ctx, RELEASE_HOLD, studentID, courseID).Err
+}
+
+// processHold checks the course as if enrolling, against the student's other holds as well, and holds a seat.
+// Held seats count toward the student's limits, and at most MaxHolds can be held at once.
+// Courses with co-requisites cannot be held, and a student on the course's waitlist waits for promotion instead.
+func (w *EnrollmentWorker) processHold(req EnrollmentRequest) (time.Time, error) {
+	studentID := req.StudentID
+	courseID := req.CourseID
+
+	if !w.cache.CourseExists(courseID) {
+		return time.Time{}, e.ErrCourseNotFound
+	}
+
+	if !w.cache.StudentExists(studentID) {
+		return time.Time{}, e.ErrStudentNotFound
+	}
+
+	if _, ok := w.cache.HoldExpiry(studentID, courseID); ok {
+		return time.Time{}, e.ErrSeatAlreadyHeld
+	}
+
+	if w.cache.IsStudentWaiting(studentID, courseID) {
+		return time.Time{}, e.ErrAlreadyWaitlisted
+	}
+
+	if w.cache.HasCorequisites(courseID) {
+		return time.Time{}, e.ErrCorequisiteUnsupported
+	}
+
+	if w.cache.HasHoldConflict(studentID, courseID) {
+		return time.Time{}, e.ErrHoldConflict
+	}
+
+	if len(w.cache.StudentHolds[studentID]) >= MaxHolds {
+		return time.Time{}, e.ErrTooManyHolds
+	}
+
+	if _, err := w.checkEnroll(studentID, courseID, Overrides{}); err != nil {
+		return time.Time{}, err
+	}
+
+	expiresAt := w.clock.Now().Add(HoldTTL)
+	w.cache.HoldSeat(studentID, courseID, expiresAt)
+	return expiresAt, nil
+}
+
+// processConfirmHold enrolls the student in the seat they hold. Every enrollment rule is checked again,
+// since the student's courses may have changed since the hold; if one fails the hold is kept until it expires.
+func (w *EnrollmentWorker) processConfirmHold(req EnrollmentRequest) error {
+	studentID := req.StudentID
+	courseID := req.CourseID
+
+	if !w.cache.CourseExists(courseID) {
+		return e.ErrCourseNotFound
+	}
+
+	if !w.cache.StudentExists(studentID) {
+		return e.ErrStudentNotFound
+	}
+
+	if _, ok := w.cache.HoldExpiry(studentID, courseID); !ok {
+		return e.ErrNoSeatHold
+	}
+
+	return w.confirmHold(studentID, courseID, Overrides{})
+}
+
+// confirmHold enrolls the student in the seat they hold, skipping the rules set in overrides
+// Assumes the student holds a seat in the course
+func (w *EnrollmentWorker) confirmHold(studentID, courseID uint, overrides Overrides) error {
+	expiresAt, _ := w.cache.HoldExpiry(studentID, courseID)
+	w.cache.ReleaseHold(studentID, courseID)
+	if !expiresAt.After(w.clock.Now()) {
+		w.promoteWaitlist(courseID)
+		return e.ErrHoldExpired
+	}
+
+	if err := w.enroll(studentID, courseID, overrides); err != nil {
+		w.cache.HoldSeat(studentID, courseID, expiresAt)
+		return err
+	}
+	return nil
+}
+
+// processReleaseHold gives the seat back; it goes to the waitlist if anyone is waiting
+func (w *EnrollmentWorker) processReleaseHold(req EnrollmentRequest) error {
+	studentID := req.StudentID
+	courseID := req.CourseID
+
+	if !w.cache.CourseExists(courseID) {
+		return e.ErrCourseNotFound
+	}
+
+	if !w.cache.StudentExists(studentID) {
+		return e.ErrStudentNotFound
+	}
+
+	if _, ok := w.cache.HoldExpiry(studentID, courseID); !ok {
+		return e.ErrNoSeatHold
+	}
+
+	w.cache.ReleaseHold(studentID, courseID)
+	w.promoteWaitlist(courseID)
+	return nil
+}
+
+// releaseExpiredHolds gives back every seat whose hold has expired, then offers the seats to the waitlists
+func (w *EnrollmentWorker) releaseExpiredHolds() {
+	expired := w.cache.ExpiredHolds(w.clock.Now())
+	if len(expired) == 0 {
+		return
+	}
+
+	released := make(map[uint]bool)
+	var courseIDs []uint
+	for _, hold := range expired {
+		w.cache.ReleaseHold(hold.StudentID, hold.CourseID)
+		log.Printf("[info] seat hold expired (student: %d, course: %d)", hold.StudentID, hold.CourseID)
+		if !released[hold.CourseID] {
+			released[hold.CourseID] = true
+			courseIDs = append(courseIDs, hold.CourseID)
+		}
+	}
+	for _, courseID := range courseIDs {
+		w.promoteWaitlist(courseID)
+	}
+}
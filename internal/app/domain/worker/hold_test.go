@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func startHoldTestWorker(t *testing.T, repo *fakeEnrollmentRepo, clock *testClock) *EnrollmentWorker {
+	t.Helper()
+	w := NewEnrollmentWorker(10, 1, repo, nil, clock)
+	data := cache.InitData{
+		Students: []models.Student{{ID: 1}, {ID: 2}, {ID: 3}},
+		Courses: []models.Course{
+			{ID: 10, Capacity: 1, Schedules: "월 09:00~10:00"},
+			{ID: 20, Capacity: 5, Schedules: "월 09:30~10:30"}, // conflicts with 10
+		},
+	}
+	if err := w.Start(data); err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+	return w
+}
+
+func TestHoldCountsAgainstCapacity(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	w := startHoldTestWorker(t, repo, &testClock{})
+
+	if _, err := w.HoldSeat(ctx, 1, 10); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if err := w.Enroll(ctx, 2, 10); !errors.Is(err, e.ErrCourseFull) {
+		t.Errorf("enroll in held seat: got %v, want %v", err, e.ErrCourseFull)
+	}
+	if _, err := w.HoldSeat(ctx, 1, 20); !errors.Is(err, e.ErrHoldConflict) {
+		t.Errorf("hold conflicting course: got %v, want %v", err, e.ErrHoldConflict)
+	}
+	if len(repo.rows) != 0 {
+		t.Errorf("a hold must not write rows, got %+v", repo.rows)
+	}
+
+	if err := w.ConfirmHold(ctx, 1, 10); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	if !w.cache.IsStudentEnrolled(1, 10) || repo.find(1, 10, false) < 0 {
+		t.Errorf("confirmed hold should be an enrollment")
+	}
+	if got := w.cache.HeldCount[10].Load(); got != 0 {
+		t.Errorf("held count after confirm: got %d, want 0", got)
+	}
+	if err := w.ConfirmHold(ctx, 1, 10); !errors.Is(err, e.ErrNoSeatHold) {
+		t.Errorf("confirm twice: got %v, want %v", err, e.ErrNoSeatHold)
+	}
+}
+
+func TestFailedConfirmKeepsHold(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	w := startHoldTestWorker(t, repo, &testClock{})
+
+	if _, err := w.HoldSeat(ctx, 1, 10); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	// the student takes a conflicting course while holding
+	if err := w.Enroll(ctx, 1, 20); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+
+	if err := w.ConfirmHold(ctx, 1, 10); !errors.Is(err, e.ErrTimeConflict) {
+		t.Errorf("confirm: got %v, want %v", err, e.ErrTimeConflict)
+	}
+	if _, ok := w.cache.HoldExpiry(1, 10); !ok {
+		t.Errorf("hold should be kept after a failed confirm")
+	}
+}
+
+func TestExpiredHoldGoesToWaitlist(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	clock := &testClock{}
+	w := startHoldTestWorker(t, repo, clock)
+
+	if _, err := w.HoldSeat(ctx, 1, 10); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if _, err := w.JoinWaitlist(ctx, 2, 10); err != nil {
+		t.Fatalf("join waitlist: %v", err)
+	}
+
+	clock.Advance(HoldTTL - 1)
+	w.submit(ctx, RELEASE_EXPIRED_HOLDS, 0, 0)
+	if _, ok := w.cache.HoldExpiry(1, 10); !ok {
+		t.Fatalf("hold released before it expired")
+	}
+
+	clock.Advance(1)
+	w.submit(ctx, RELEASE_EXPIRED_HOLDS, 0, 0)
+	if _, ok := w.cache.HoldExpiry(1, 10); ok {
+		t.Errorf("expired hold should be released")
+	}
+	if !w.cache.IsStudentEnrolled(2, 10) {
+		t.Errorf("released seat should go to the waitlisted student")
+	}
+	if err := w.ConfirmHold(ctx, 1, 10); !errors.Is(err, e.ErrNoSeatHold) {
+		t.Errorf("confirm after expiry: got %v, want %v", err, e.ErrNoSeatHold)
+	}
+}
+
+func TestConfirmAfterExpiryBeforeSweep(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	clock := &testClock{}
+	w := startHoldTestWorker(t, repo, clock)
+
+	if _, err := w.HoldSeat(ctx, 1, 10); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	clock.Advance(HoldTTL)
+
+	if err := w.ConfirmHold(ctx, 1, 10); !errors.Is(err, e.ErrHoldExpired) {
+		t.Errorf("confirm: got %v, want %v", err, e.ErrHoldExpired)
+	}
+	if err := w.Enroll(ctx, 2, 10); err != nil {
+		t.Errorf("seat should be free again: %v", err)
+	}
+}
+
+func TestHoldLimits(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	courses := []models.Course{
+		{ID: 10, Capacity: 5, Credits: 3, Schedules: "월 09:00~10:00"},
+		{ID: 20, Capacity: 5, Credits: 3, Schedules: "화 09:00~10:00"},
+		{ID: 30, Capacity: 5, Credits: 3, Schedules: "수 09:00~10:00"},
+		{ID: 40, Capacity: 5, Credits: 3, Schedules: "목 09:00~10:00"},
+		{ID: 50, Capacity: 5, Credits: 3, Schedules: "금 09:00~10:00"},
+	}
+	w := NewEnrollmentWorker(10, 1, repo, nil, &testClock{})
+	err := w.Start(cache.InitData{
+		Students:      []models.Student{{ID: 1}, {ID: 2}},
+		Courses:       courses,
+		StudentLimits: map[uint]cache.Limit{1: {MaxCourses: 2}},
+	})
+	if err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+
+	// Held seats count toward the course limit, for holds and enrollments alike
+	if _, err := w.HoldSeat(ctx, 1, 10); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if err := w.Enroll(ctx, 1, 20); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if _, err := w.HoldSeat(ctx, 1, 30); !errors.Is(err, e.ErrCourseLimitExceeded) {
+		t.Errorf("hold over the limit: got %v, want %v", err, e.ErrCourseLimitExceeded)
+	}
+	if err := w.Enroll(ctx, 1, 30); !errors.Is(err, e.ErrCourseLimitExceeded) {
+		t.Errorf("enroll over the limit with a held seat: got %v, want %v", err, e.ErrCourseLimitExceeded)
+	}
+	if err := w.ConfirmHold(ctx, 1, 10); err != nil {
+		t.Errorf("confirming a held seat within the limit: %v", err)
+	}
+
+	// Without limits the number of holds is still capped
+	for i, course := range courses {
+		_, err := w.HoldSeat(ctx, 2, course.ID)
+		if i < MaxHolds && err != nil {
+			t.Errorf("hold %d: %v", i+1, err)
+		}
+		if i >= MaxHolds && !errors.Is(err, e.ErrTooManyHolds) {
+			t.Errorf("hold %d: got %v, want %v", i+1, err, e.ErrTooManyHolds)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"course-reg/internal/app/domain/journal"
+	"course-reg/internal/app/models"
+	"course-reg/internal/app/repository"
+	"log"
+	"time"
+)
+
+// Journal returns the worker's journal, or nil if enrollments are written to the DB directly
+func (w *EnrollmentWorker) Journal() *journal.Journal {
+	return w.journal
+}
+
+// flusher applies the journal to the DB every journalFlushInterval until stop is closed.
+// While the DB is down the rows stay journaled, and only the start and end of the outage are logged.
+func (w *EnrollmentWorker) flusher(stop chan struct{}) {
+	ticker := time.NewTicker(journalFlushInterval)
+	defer ticker.Stop()
+
+	failing := false
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			err := w.flushJournal()
+			if err != nil && !failing {
+				log.Printf("[error] flush enrollment journal failed, retrying every %v: %v", journalFlushInterval, err)
+			} else if err == nil && failing {
+				log.Println("[info] enrollment journal flushed again")
+			}
+			failing = err != nil
+		}
+	}
+}
+
+// DeleteAllEnrollments deletes the term's enrollment rows. It holds the worker lock, and the journal is
+// applied first, so rows still pending in it are not replayed into the DB after the reset.
+func (w *EnrollmentWorker) DeleteAllEnrollments(termID uint) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enrollRepo.DeleteAllEnrollments(termID)
+}
+
+func (w *EnrollmentWorker) flushJournal() error {
+	return w.journal.Flush(w.enrollRepo.InsertEnrollmentsIfAbsent)
+}
+
+// withJournaled adds the journaled rows of the term that are not in the DB yet to the loaded enrollments
+func withJournaled(enrollments, journaled []models.Enrollment, termID uint) []models.Enrollment {
+	type key struct{ studentID, courseID uint }
+	loaded := make(map[key]struct{}, len(enrollments))
+	for _, row := range enrollments {
+		if !row.IsWaitlist {
+			loaded[key{row.StudentID, row.CourseID}] = struct{}{}
+		}
+	}
+
+	for _, row := range journaled {
+		if _, ok := loaded[key{row.StudentID, row.CourseID}]; ok || row.TermID != termID {
+			continue
+		}
+		enrollments = append(enrollments, row)
+	}
+	return enrollments
+}
+
+// flushingRepository applies the journal before every other enrollment write,
+// so the DB sees the worker's changes in the order they were made
+type flushingRepository struct {
+	repository.EnrollmentRepositoryInterface
+	journal *journal.Journal
+}
+
+func (r *flushingRepository) flush() error {
+	return r.journal.Flush(r.EnrollmentRepositoryInterface.InsertEnrollmentsIfAbsent)
+}
+
+func (r *flushingRepository) InsertEnrollment(enrollment *models.Enrollment) error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	return r.EnrollmentRepositoryInterface.InsertEnrollment(enrollment)
+}
+
+func (r *flushingRepository) BatchInsertEnrollments(enrollments []models.Enrollment) error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	return r.EnrollmentRepositoryInterface.BatchInsertEnrollments(enrollments)
+}
+
+func (r *flushingRepository) DeleteEnrollment(studentID uint, courseID uint) error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	return r.EnrollmentRepositoryInterface.DeleteEnrollment(studentID, courseID)
+}
+
+func (r *flushingRepository) DeleteEnrollments(studentID uint, courseIDs []uint) error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	return r.EnrollmentRepositoryInterface.DeleteEnrollments(studentID, courseIDs)
+}
+
+func (r *flushingRepository) DeleteWaitlistEntry(studentID uint, courseID uint) error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	return r.EnrollmentRepositoryInterface.DeleteWaitlistEntry(studentID, courseID)
+}
+
+func (r *flushingRepository) PromoteWaitlistEntry(studentID uint, courseID uint, position int) error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	return r.EnrollmentRepositoryInterface.PromoteWaitlistEntry(studentID, courseID, position)
+}
+
+func (r *flushingRepository) SwapEnrollment(studentID uint, fromCourseID uint, toCourseID uint, position int) error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	return r.EnrollmentRepositoryInterface.SwapEnrollment(studentID, fromCourseID, toCourseID, position)
+}
+
+func (r *flushingRepository) DeleteAllEnrollments(termID uint) error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	return r.EnrollmentRepositoryInterface.DeleteAllEnrollments(termID)
+}
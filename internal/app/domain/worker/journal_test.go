@@ -0,0 +1,132 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/journal"
+	"course-reg/internal/app/models"
+)
+
+// setDown makes every write fail, like a DB outage
+func (r *syncEnrollmentRepo) setDown(down bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failBatch = down
+	r.failInsert = down
+}
+
+func (r *syncEnrollmentRepo) hasRow(studentID, courseID uint) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.find(studentID, courseID, false) >= 0
+}
+
+func TestJournalSurvivesDBOutage(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "enrollments.journal")
+	j, err := journal.Open(path)
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	t.Cleanup(func() { j.Close() })
+
+	repo := &syncEnrollmentRepo{fakeEnrollmentRepo: &fakeEnrollmentRepo{}}
+	data := cache.InitData{
+		TermID:   1,
+		Students: []models.Student{{ID: 1}, {ID: 2}},
+		Courses:  []models.Course{{ID: 10, Capacity: 1, Schedules: "월 09:00~10:00"}},
+	}
+	w := NewEnrollmentWorker(10, 1, repo, j, &testClock{})
+	if err := w.Start(data); err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+
+	repo.setDown(true)
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Fatalf("enroll while the DB is down: %v", err)
+	}
+	if err := w.Enroll(ctx, 2, 10); !errors.Is(err, e.ErrCourseFull) {
+		t.Errorf("journaled seat should count: got %v, want %v", err, e.ErrCourseFull)
+	}
+	if err := w.Cancel(ctx, 1, 10); !errors.Is(err, e.ErrEnrollmentDBFailed) {
+		t.Errorf("cancel needs the journal applied first: got %v, want %v", err, e.ErrEnrollmentDBFailed)
+	}
+	w.Stop()
+
+	// The restarted worker loads the journaled row, which is not in the DB yet
+	if repo.hasRow(1, 10) {
+		t.Fatalf("row should not reach the DB while it is down")
+	}
+	data.Enrollments = repo.rows
+	w = NewEnrollmentWorker(10, 1, repo, j, &testClock{})
+	if err := w.Start(data); err != nil {
+		t.Fatalf("restart worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+	if !w.cache.IsStudentEnrolled(1, 10) {
+		t.Errorf("journaled enrollment should be loaded on start")
+	}
+
+	repo.setDown(false)
+	deadline := time.Now().Add(5 * time.Second)
+	for !repo.hasRow(1, 10) {
+		if time.Now().After(deadline) {
+			t.Fatalf("flusher did not apply the journal")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := w.Cancel(ctx, 1, 10); err != nil {
+		t.Errorf("cancel after the DB is back: %v", err)
+	}
+	if rows := j.Pending(); len(rows) != 0 {
+		t.Errorf("journal should be empty, got %+v", rows)
+	}
+}
+
+func TestResetDiscardsJournaledRows(t *testing.T) {
+	ctx := context.Background()
+	j, err := journal.Open(filepath.Join(t.TempDir(), "enrollments.journal"))
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	t.Cleanup(func() { j.Close() })
+
+	repo := &syncEnrollmentRepo{fakeEnrollmentRepo: &fakeEnrollmentRepo{}}
+	data := cache.InitData{
+		TermID:   1,
+		Students: []models.Student{{ID: 1}},
+		Courses:  []models.Course{{ID: 10, Capacity: 1, Schedules: "월 09:00~10:00"}},
+	}
+	w := NewEnrollmentWorker(10, 1, repo, j, &testClock{})
+	if err := w.Start(data); err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	repo.setDown(true)
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Fatalf("enroll while the DB is down: %v", err)
+	}
+	w.Stop() // the last flush fails, so the row stays journaled
+
+	repo.setDown(false)
+	if err := w.DeleteAllEnrollments(1); err != nil {
+		t.Fatalf("delete all enrollments: %v", err)
+	}
+	if len(repo.rows) != 0 || len(j.Pending()) != 0 {
+		t.Fatalf("reset should leave no rows, got DB %+v and journal %+v", repo.rows, j.Pending())
+	}
+
+	w = NewEnrollmentWorker(10, 1, repo, j, &testClock{})
+	if err := w.Start(data); err != nil {
+		t.Fatalf("restart worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+	if w.cache.IsStudentEnrolled(1, 10) {
+		t.Errorf("reset enrollment came back from the journal")
+	}
+}
@@ -0,0 +1,99 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func TestEnrollLimits(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	courses := []models.Course{
+		{ID: 10, Capacity: 5, Credits: 3, Schedules: "월 09:00~10:00"},
+		{ID: 20, Capacity: 5, Credits: 3, Schedules: "화 09:00~10:00"},
+		{ID: 30, Capacity: 5, Credits: 2, Schedules: "수 09:00~10:00"},
+		{ID: 40, Capacity: 5, Credits: 1, Schedules: "목 09:00~10:00"},
+	}
+	w := NewEnrollmentWorker(10, 1, repo, nil, &testClock{})
+	err := w.Start(cache.InitData{
+		Students:      []models.Student{{ID: 1}, {ID: 2}},
+		Courses:       courses,
+		DefaultLimit:  cache.Limit{MaxCourses: 3, MaxCredits: 7},
+		StudentLimits: map[uint]cache.Limit{2: {MaxCourses: 1}},
+	})
+	if err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.Enroll(ctx, 1, 20); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.Enroll(ctx, 1, 30); !errors.Is(err, e.ErrCreditLimitExceeded) {
+		t.Errorf("8 credits: got %v, want %v", err, e.ErrCreditLimitExceeded)
+	}
+	if err := w.Swap(ctx, 1, 20, 30); err != nil {
+		t.Errorf("swap down to 5 credits: %v", err)
+	}
+	if err := w.Enroll(ctx, 1, 40); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.Enroll(ctx, 1, 20); !errors.Is(err, e.ErrCourseLimitExceeded) {
+		t.Errorf("4th course: got %v, want %v", err, e.ErrCourseLimitExceeded)
+	}
+
+	// Student override
+	if err := w.Enroll(ctx, 2, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.Enroll(ctx, 2, 40); !errors.Is(err, e.ErrCourseLimitExceeded) {
+		t.Errorf("override: got %v, want %v", err, e.ErrCourseLimitExceeded)
+	}
+	if err := w.AdminEnroll(ctx, 2, 40, Overrides{IgnoreLimits: true}); err != nil {
+		t.Errorf("admin enroll ignoring limits: %v", err)
+	}
+}
+
+func TestSetStudentLimitWhileRunning(t *testing.T) {
+	ctx := context.Background()
+	w := NewEnrollmentWorker(10, 2, &fakeEnrollmentRepo{}, nil, &testClock{})
+	err := w.Start(cache.InitData{
+		Students: []models.Student{{ID: 1}},
+		Courses: []models.Course{
+			{ID: 10, Capacity: 5, Credits: 3, Schedules: "월 09:00~10:00"},
+			{ID: 20, Capacity: 5, Credits: 3, Schedules: "화 09:00~10:00"},
+			{ID: 30, Capacity: 5, Credits: 3, Schedules: "수 09:00~10:00"},
+		},
+		DefaultLimit: cache.Limit{MaxCourses: 3},
+	})
+	if err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	if err := w.SetStudentLimit(ctx, 1, &cache.Limit{MaxCourses: 1}); err != nil {
+		t.Fatalf("set limit: %v", err)
+	}
+	if err := w.Enroll(ctx, 1, 20); !errors.Is(err, e.ErrCourseLimitExceeded) {
+		t.Errorf("lowered override: got %v, want %v", err, e.ErrCourseLimitExceeded)
+	}
+
+	// Removing the override brings back the default limit
+	if err := w.SetStudentLimit(ctx, 1, nil); err != nil {
+		t.Fatalf("delete limit: %v", err)
+	}
+	if err := w.Enroll(ctx, 1, 20); err != nil {
+		t.Errorf("default limit: %v", err)
+	}
+}
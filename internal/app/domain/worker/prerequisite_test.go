@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func TestPrerequisites(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	w := NewEnrollmentWorker(10, 1, repo, nil, &testClock{})
+	err := w.Start(cache.InitData{
+		Students: []models.Student{{ID: 1}, {ID: 2}, {ID: 3}},
+		Courses: []models.Course{
+			{ID: 20, Code: "CS201", Capacity: 1, Schedules: "월 09:00~10:00"},
+			{ID: 21, Code: "CS201", Capacity: 1, Schedules: "화 09:00~10:00"}, // another section
+		},
+		// CS101 was taught in a previous term
+		Completions:   []models.CourseCompletion{{StudentID: 1, CourseCode: "CS101"}, {StudentID: 3, CourseCode: "CS101"}},
+		Prerequisites: []models.CoursePrerequisite{{CourseCode: "CS201", PrerequisiteCode: "CS101"}},
+	})
+	if err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+
+	if err := w.Enroll(ctx, 2, 20); !errors.Is(err, e.ErrPrerequisiteNotMet) {
+		t.Errorf("not completed: got %v, want %v", err, e.ErrPrerequisiteNotMet)
+	}
+	if err := w.Enroll(ctx, 2, 21); !errors.Is(err, e.ErrPrerequisiteNotMet) {
+		t.Errorf("same course code: got %v, want %v", err, e.ErrPrerequisiteNotMet)
+	}
+	if err := w.Enroll(ctx, 1, 20); err != nil {
+		t.Errorf("completed: %v", err)
+	}
+	if _, err := w.JoinWaitlist(ctx, 2, 20); !errors.Is(err, e.ErrPrerequisiteNotMet) {
+		t.Errorf("waitlist: got %v, want %v", err, e.ErrPrerequisiteNotMet)
+	}
+	if _, err := w.JoinWaitlist(ctx, 3, 20); err != nil {
+		t.Errorf("waitlist after completing: %v", err)
+	}
+	if err := w.AdminEnroll(ctx, 2, 20, Overrides{BypassEligibility: true, IgnoreCapacity: true}); err != nil {
+		t.Errorf("admin bypassing eligibility: %v", err)
+	}
+}
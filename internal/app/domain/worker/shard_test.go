@@ -0,0 +1,169 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+// syncEnrollmentRepo makes fakeEnrollmentRepo safe for concurrent shards
+type syncEnrollmentRepo struct {
+	mu sync.Mutex
+	*fakeEnrollmentRepo
+}
+
+func (r *syncEnrollmentRepo) InsertEnrollment(enrollment *models.Enrollment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fakeEnrollmentRepo.InsertEnrollment(enrollment)
+}
+
+func (r *syncEnrollmentRepo) BatchInsertEnrollments(enrollments []models.Enrollment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fakeEnrollmentRepo.BatchInsertEnrollments(enrollments)
+}
+
+func (r *syncEnrollmentRepo) InsertEnrollmentsIfAbsent(enrollments []models.Enrollment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fakeEnrollmentRepo.InsertEnrollmentsIfAbsent(enrollments)
+}
+
+func (r *syncEnrollmentRepo) DeleteEnrollment(studentID uint, courseID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fakeEnrollmentRepo.DeleteEnrollment(studentID, courseID)
+}
+
+func (r *syncEnrollmentRepo) DeleteEnrollments(studentID uint, courseIDs []uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fakeEnrollmentRepo.DeleteEnrollments(studentID, courseIDs)
+}
+
+func (r *syncEnrollmentRepo) DeleteWaitlistEntry(studentID uint, courseID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fakeEnrollmentRepo.DeleteWaitlistEntry(studentID, courseID)
+}
+
+func (r *syncEnrollmentRepo) PromoteWaitlistEntry(studentID uint, courseID uint, position int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fakeEnrollmentRepo.PromoteWaitlistEntry(studentID, courseID, position)
+}
+
+func (r *syncEnrollmentRepo) SwapEnrollment(studentID uint, fromCourseID uint, toCourseID uint, position int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fakeEnrollmentRepo.SwapEnrollment(studentID, fromCourseID, toCourseID, position)
+}
+
+// TestShardedStress runs a mix of requests from many clients and checks that capacity is never exceeded,
+// no student ends up with a time conflict, and the DB rows match the cache. Run it with -race.
+func TestShardedStress(t *testing.T) {
+	const (
+		numStudents = 200
+		numCourses  = 24
+		capacity    = 8
+		numClients  = 32
+		numRequests = 300
+		numSpike    = 50 // requests per client at the start that are all enrollments, to fill courses concurrently
+	)
+
+	students := make([]models.Student, numStudents)
+	for i := range students {
+		students[i].ID = uint(i + 1)
+	}
+	// Courses starting an hour apart on the same day overlap by half an hour
+	courses := make([]models.Course, numCourses)
+	for i := range courses {
+		day := []string{"월", "화"}[i%2]
+		start := 9 + (i/2)%6
+		courses[i] = models.Course{ID: uint(i + 1), Capacity: capacity, Schedules: fmt.Sprintf("%s %02d:00~%02d:30", day, start, start+1)}
+	}
+
+	for _, shards := range []int{1, 8} {
+		t.Run(fmt.Sprintf("shards=%d", shards), func(t *testing.T) {
+			ctx := context.Background()
+			repo := &syncEnrollmentRepo{fakeEnrollmentRepo: &fakeEnrollmentRepo{}}
+			w := NewEnrollmentWorker(numClients*shards, shards, repo, nil, &testClock{})
+			if err := w.Start(cache.InitData{Students: students, Courses: courses}); err != nil {
+				t.Fatalf("start worker: %v", err)
+			}
+			t.Cleanup(w.Stop)
+
+			var wg sync.WaitGroup
+			for client := range numClients {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					r := rand.New(rand.NewPCG(1, uint64(client)))
+					for i := range numRequests {
+						studentID := uint(r.IntN(numStudents) + 1)
+						courseID := uint(r.IntN(numCourses) + 1)
+
+						op := r.IntN(10)
+						if i < numSpike {
+							op = 0
+						}
+						var err error
+						switch {
+						case op < 6:
+							err = w.Enroll(ctx, studentID, courseID)
+						case op < 8:
+							err = w.Cancel(ctx, studentID, courseID)
+						case op < 9:
+							_, err = w.JoinWaitlist(ctx, studentID, courseID)
+						default:
+							err = w.Swap(ctx, studentID, uint(r.IntN(numCourses)+1), courseID)
+						}
+						if errors.Is(err, e.ErrEnrollmentDBFailed) || errors.Is(err, e.ErrServerBusy) {
+							t.Errorf("student %d, course %d: %v", studentID, courseID, err)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			enrolled := make(map[uint]int)
+			for studentID, courseIDs := range w.cache.StudentCourses {
+				for courseID := range courseIDs {
+					enrolled[courseID]++
+					for other := range courseIDs {
+						if w.cache.ConflictGraph[courseID][other] {
+							t.Errorf("student %d has conflicting courses %d and %d", studentID, courseID, other)
+						}
+					}
+					if repo.find(studentID, courseID, false) < 0 {
+						t.Errorf("missing enrollment row (student: %d, course: %d)", studentID, courseID)
+					}
+				}
+				for courseID := range w.cache.StudentWaitingCourses[studentID] {
+					if repo.find(studentID, courseID, true) < 0 {
+						t.Errorf("missing waitlist row (student: %d, course: %d)", studentID, courseID)
+					}
+				}
+			}
+			total := 0
+			for _, course := range courses {
+				count := int(w.cache.EnrolledCount[course.ID].Load())
+				if count > capacity || count != enrolled[course.ID] {
+					t.Errorf("course %d: count %d, enrolled students %d, capacity %d", course.ID, count, enrolled[course.ID], capacity)
+				}
+				total += count + int(w.cache.WaitingCount[course.ID].Load())
+			}
+			if len(repo.rows) != total {
+				t.Errorf("rows: got %d, want %d", len(repo.rows), total)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func TestSpecialCourses(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeEnrollmentRepo{}
+	w := NewEnrollmentWorker(10, 1, repo, nil, &testClock{})
+	err := w.Start(cache.InitData{
+		Students: []models.Student{{ID: 1, Cohort: "senior"}, {ID: 2}, {ID: 3}},
+		Courses: []models.Course{
+			{ID: 10, Capacity: 5, IsSpecial: true, Schedules: "월 09:00~10:00"},
+			{ID: 20, Capacity: 5, IsSpecial: true, Schedules: "화 09:00~10:00"},
+			{ID: 30, Capacity: 5, Schedules: "수 09:00~10:00"},
+		},
+		SpecialRules: []models.SpecialCourseRule{
+			{CourseID: 10, StudentIDs: []uint{3}, Cohorts: []string{"senior"}},
+			{CourseID: 30, StudentIDs: []uint{3}}, // not special, ignored
+		},
+		MaxSpecialCourses: 1,
+	})
+	if err != nil {
+		t.Fatalf("start worker: %v", err)
+	}
+	t.Cleanup(w.Stop)
+
+	if err := w.Enroll(ctx, 1, 10); err != nil {
+		t.Errorf("eligible by cohort: %v", err)
+	}
+	if err := w.Enroll(ctx, 2, 10); !errors.Is(err, e.ErrNotEligibleForCourse) {
+		t.Errorf("not eligible: got %v, want %v", err, e.ErrNotEligibleForCourse)
+	}
+	if err := w.AdminEnroll(ctx, 2, 10, Overrides{BypassEligibility: true}); err != nil {
+		t.Errorf("admin bypassing eligibility: %v", err)
+	}
+	if err := w.Enroll(ctx, 3, 30); err != nil {
+		t.Errorf("rules on regular courses are ignored: %v", err)
+	}
+
+	// Course 20 has no rule, so only the special course limit applies
+	if err := w.Enroll(ctx, 1, 20); !errors.Is(err, e.ErrSpecialLimitExceeded) {
+		t.Errorf("second special course: got %v, want %v", err, e.ErrSpecialLimitExceeded)
+	}
+	if err := w.Swap(ctx, 1, 10, 20); err != nil {
+		t.Errorf("swapping one special course for another: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"course-reg/internal/app/domain/e"
+	"fmt"
+)
+
+// Swap drops fromCourseID and enrolls in toCourseID as one step; if the new course fails, the old one is kept
+func (w *EnrollmentWorker) Swap(ctx context.Context, studentID, fromCourseID, toCourseID uint) error {
+	return w.submitRequest(ctx, EnrollmentRequest{
+		Type:       SWAP,
+		StudentID:  studentID,
+		CourseID:   fromCourseID,
+		ToCourseID: toCourseID,
+	}).Err
+}
+
+// processSwap checks the new course as if the old one were already dropped, then changes both in one DB transaction.
+// Moving between courses of the same exclusive group is allowed; courses with co-requisites cannot be swapped.
+func (w *EnrollmentWorker) processSwap(req EnrollmentRequest) error {
+	studentID := req.StudentID
+	fromCourseID := req.CourseID
+	toCourseID := req.ToCourseID
+
+	if fromCourseID == toCourseID {
+		return fmt.Errorf("%w: cannot swap a course with itself", e.ErrInvalidInput)
+	}
+
+	if !w.cache.CourseExists(fromCourseID) || !w.cache.CourseExists(toCourseID) {
+		return e.ErrCourseNotFound
+	}
+
+	if !w.cache.StudentExists(studentID) {
+		return e.ErrStudentNotFound
+	}
+
+	if !w.cache.IsStudentEnrolled(studentID, fromCourseID) {
+		return e.ErrNotEnrolled
+	}
+
+	if w.cache.IsStudentEnrolled(studentID, toCourseID) {
+		return e.ErrAlreadyEnrolled
+	}
+
+	if _, ok := w.cache.HoldExpiry(studentID, toCourseID); ok {
+		return e.ErrSeatAlreadyHeld
+	}
+
+	if w.cache.HasTimeConflictExcept(studentID, toCourseID, fromCourseID) {
+		return e.ErrTimeConflict
+	}
+
+	if w.cache.HasCorequisites(fromCourseID) || w.cache.HasCorequisites(toCourseID) {
+		return e.ErrCorequisiteUnsupported
+	}
+
+	if w.cache.HasExclusiveConflict(studentID, toCourseID, fromCourseID) {
+		return e.ErrExclusiveCourseConflict
+	}
+
+	if err := w.checkPrerequisites(studentID, toCourseID); err != nil {
+		return err
+	}
+
+	if err := w.cache.CheckEligibility(studentID, toCourseID); err != nil {
+		return err
+	}
+
+	if err := w.checkLimits(studentID, toCourseID, fromCourseID); err != nil {
+		return err
+	}
+
+	pos, err := w.cache.GetPosIfNotFull(toCourseID)
+	if err != nil {
+		return e.ErrCourseFull
+	}
+
+	if err := w.enrollRepo.SwapEnrollment(studentID, fromCourseID, toCourseID, pos); err != nil {
+		return fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)
+	}
+	w.cache.CancelStudent(studentID, fromCourseID)
+	if w.cache.IsStudentWaiting(studentID, toCourseID) {
+		w.cache.RemoveFromWaitlist(studentID, toCourseID)
+	}
+	w.cache.EnrollStudent(studentID, toCourseID)
+
+	// Same as a cancel: the dropped seat goes to the waitlist, and this student's other waitlists may now fit
+	w.promoteWaitlist(fromCourseID)
+	for waitingCourseID := range w.cache.StudentWaitingCourses[studentID] {
+		w.promoteWaitlist(waitingCourseID)
+	}
+
+	return nil
+}
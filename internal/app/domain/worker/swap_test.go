@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func TestSwap(t *testing.T) {
+	ctx := context.Background()
+	students := []models.Student{{ID: 1}, {ID: 2}, {ID: 3}}
+	courses := []models.Course{
+		{ID: 10, Capacity: 1, Schedules: "월 09:00~10:00"},
+		{ID: 20, Capacity: 1, Schedules: "월 09:30~10:30"}, // conflicts with 10
+		{ID: 30, Capacity: 1, Schedules: "화 09:00~10:00"},
+	}
+
+	t.Run("swap into conflicting course", func(t *testing.T) {
+		repo := &fakeEnrollmentRepo{}
+		w := startTestWorker(t, repo, students, courses)
+		if err := w.Enroll(ctx, 1, 10); err != nil {
+			t.Fatalf("enroll: %v", err)
+		}
+		if _, err := w.JoinWaitlist(ctx, 2, 10); err != nil {
+			t.Fatalf("join waitlist: %v", err)
+		}
+
+		if err := w.Swap(ctx, 1, 10, 20); err != nil {
+			t.Fatalf("swap: %v", err)
+		}
+		if w.cache.IsStudentEnrolled(1, 10) || !w.cache.IsStudentEnrolled(1, 20) {
+			t.Errorf("student 1 should have moved from 10 to 20")
+		}
+		if repo.find(1, 10, false) >= 0 || repo.find(1, 20, false) < 0 {
+			t.Errorf("rows not swapped: %v", repo.rows)
+		}
+		if !w.cache.IsStudentEnrolled(2, 10) {
+			t.Errorf("freed seat in course 10 should go to the waitlist")
+		}
+	})
+
+	t.Run("keeps old course when new one fails", func(t *testing.T) {
+		repo := &fakeEnrollmentRepo{}
+		w := startTestWorker(t, repo, students, courses)
+		if err := w.Enroll(ctx, 1, 10); err != nil {
+			t.Fatalf("enroll: %v", err)
+		}
+		if err := w.Enroll(ctx, 2, 30); err != nil {
+			t.Fatalf("enroll: %v", err)
+		}
+
+		if err := w.Swap(ctx, 1, 10, 30); !errors.Is(err, e.ErrCourseFull) {
+			t.Errorf("swap into full course: got %v, want %v", err, e.ErrCourseFull)
+		}
+		if err := w.Swap(ctx, 3, 10, 20); !errors.Is(err, e.ErrNotEnrolled) {
+			t.Errorf("swap without old course: got %v, want %v", err, e.ErrNotEnrolled)
+		}
+		if !w.cache.IsStudentEnrolled(1, 10) || repo.find(1, 10, false) < 0 {
+			t.Errorf("student 1 should still be enrolled in course 10")
+		}
+	})
+
+	t.Run("takes seat from own waitlist entry", func(t *testing.T) {
+		repo := &fakeEnrollmentRepo{}
+		w := startTestWorker(t, repo, students, courses)
+		if err := w.Enroll(ctx, 2, 20); err != nil {
+			t.Fatalf("enroll: %v", err)
+		}
+		if _, err := w.JoinWaitlist(ctx, 1, 20); err != nil {
+			t.Fatalf("join waitlist: %v", err)
+		}
+		if err := w.Enroll(ctx, 1, 10); err != nil {
+			t.Fatalf("enroll: %v", err)
+		}
+		// Promotion skips student 1 because course 20 conflicts with course 10
+		if err := w.Cancel(ctx, 2, 20); err != nil {
+			t.Fatalf("cancel: %v", err)
+		}
+		if w.cache.IsStudentEnrolled(1, 20) {
+			t.Fatalf("student 1 should not have been promoted")
+		}
+
+		if err := w.Swap(ctx, 1, 10, 20); err != nil {
+			t.Fatalf("swap: %v", err)
+		}
+		if !w.cache.IsStudentEnrolled(1, 20) || w.cache.IsStudentWaiting(1, 20) {
+			t.Errorf("student 1 should be enrolled in course 20 and off its waitlist")
+		}
+		if repo.find(1, 20, true) >= 0 || repo.find(1, 20, false) < 0 {
+			t.Errorf("waitlist row should have been promoted: %v", repo.rows)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package worker
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"fmt"
+	"log"
+)
+
+// processJoinWaitlist handles waitlist join logic and returns the 1-based waitlist position.
+// Courses with co-requisites have no waitlist, since a promotion could not take the partner courses with it.
+func (w *EnrollmentWorker) processJoinWaitlist(req EnrollmentRequest) (int, error) {
+	studentID := req.StudentID
+	courseID := req.CourseID
+
+	if !w.cache.CourseExists(courseID) {
+		return 0, e.ErrCourseNotFound
+	}
+
+	if !w.cache.StudentExists(studentID) {
+		return 0, e.ErrStudentNotFound
+	}
+
+	if w.cache.IsStudentEnrolled(studentID, courseID) {
+		return 0, e.ErrAlreadyEnrolled
+	}
+
+	if w.cache.IsStudentWaiting(studentID, courseID) {
+		return 0, e.ErrAlreadyWaitlisted
+	}
+
+	if _, ok := w.cache.HoldExpiry(studentID, courseID); ok {
+		return 0, e.ErrSeatAlreadyHeld
+	}
+
+	if w.cache.HasTimeConflict(studentID, courseID) {
+		return 0, e.ErrTimeConflict
+	}
+
+	if w.cache.HasCorequisites(courseID) {
+		return 0, e.ErrCorequisiteUnsupported
+	}
+
+	if w.cache.HasExclusiveConflict(studentID, courseID) {
+		return 0, e.ErrExclusiveCourseConflict
+	}
+
+	if err := w.checkPrerequisites(studentID, courseID); err != nil {
+		return 0, err
+	}
+
+	if err := w.cache.CheckEligibility(studentID, courseID); err != nil {
+		return 0, err
+	}
+
+	if err := w.checkLimits(studentID, courseID); err != nil {
+		return 0, err
+	}
+
+	if _, err := w.cache.GetPosIfNotFull(courseID); err == nil {
+		return 0, e.ErrCourseNotFull
+	}
+
+	if w.cache.IsWaitlistFull(courseID) {
+		return 0, e.ErrWaitlistFull
+	}
+
+	pos := int(w.cache.WaitingCount[courseID].Load())
+	if err := w.enrollRepo.InsertEnrollment(&models.Enrollment{TermID: w.cache.TermID, StudentID: studentID, CourseID: courseID, Position: pos, IsWaitlist: true}); err != nil {
+		return 0, fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)
+	}
+
+	return w.cache.AddToWaitlist(studentID, courseID), nil
+}
+
+// processLeaveWaitlist handles waitlist leave logic
+func (w *EnrollmentWorker) processLeaveWaitlist(req EnrollmentRequest) error {
+	studentID := req.StudentID
+	courseID := req.CourseID
+
+	if !w.cache.CourseExists(courseID) {
+		return e.ErrCourseNotFound
+	}
+
+	if !w.cache.StudentExists(studentID) {
+		return e.ErrStudentNotFound
+	}
+
+	if !w.cache.IsStudentWaiting(studentID, courseID) {
+		return e.ErrNotWaitlisted
+	}
+
+	if err := w.enrollRepo.DeleteWaitlistEntry(studentID, courseID); err != nil {
+		return fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)
+	}
+	w.cache.RemoveFromWaitlist(studentID, courseID)
+
+	return nil
+}
+
+// promoteWaitlist fills free seats of a course from its waitlist in order.
+// Students who would have a time conflict or an exclusive group conflict, miss a prerequisite, are not eligible,
+// would go over their limits or miss a co-requisite are skipped and keep their place in line.
+func (w *EnrollmentWorker) promoteWaitlist(courseID uint) {
+	waitlist := append([]uint(nil), w.cache.CourseWaitlist[courseID]...)
+	for _, studentID := range waitlist {
+		pos, err := w.cache.GetPosIfNotFull(courseID)
+		if err != nil {
+			return
+		}
+
+		if w.cache.HasTimeConflict(studentID, courseID) {
+			log.Printf("[info] waitlist promotion skipped (student: %d, course: %d): time conflict", studentID, courseID)
+			continue
+		}
+
+		if w.cache.HasExclusiveConflict(studentID, courseID) {
+			log.Printf("[info] waitlist promotion skipped (student: %d, course: %d): exclusive group conflict", studentID, courseID)
+			continue
+		}
+
+		if err := w.checkPrerequisites(studentID, courseID); err != nil {
+			log.Printf("[info] waitlist promotion skipped (student: %d, course: %d): %v", studentID, courseID, err)
+			continue
+		}
+
+		if err := w.cache.CheckEligibility(studentID, courseID); err != nil {
+			log.Printf("[info] waitlist promotion skipped (student: %d, course: %d): %v", studentID, courseID, err)
+			continue
+		}
+
+		if len(w.cache.MissingCorequisites(studentID, courseID)) > 0 {
+			log.Printf("[info] waitlist promotion skipped (student: %d, course: %d): co-requisite not enrolled", studentID, courseID)
+			continue
+		}
+
+		if err := w.checkLimits(studentID, courseID); err != nil {
+			log.Printf("[info] waitlist promotion skipped (student: %d, course: %d): %v", studentID, courseID, err)
+			continue
+		}
+
+		if err := w.promoteStudent(studentID, courseID, pos); err != nil {
+			log.Printf("[error] waitlist promotion failed (student: %d, course: %d): %v", studentID, courseID, err)
+			return
+		}
+		log.Printf("[info] waitlist promoted (student: %d, course: %d)", studentID, courseID)
+	}
+}
+
+// promoteStudent turns a student's waitlist entry into an enrollment at the given position
+// Assumes the student is on the waitlist and the seat is free
+func (w *EnrollmentWorker) promoteStudent(studentID, courseID uint, pos int) error {
+	if err := w.enrollRepo.PromoteWaitlistEntry(studentID, courseID, pos); err != nil {
+		return fmt.Errorf("%w: %v", e.ErrEnrollmentDBFailed, err)
+	}
+	w.cache.RemoveFromWaitlist(studentID, courseID)
+	w.cache.EnrollStudent(studentID, courseID)
+	return nil
+}
@@ -2,8 +2,20 @@ package worker
 
 import (
 	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/journal"
 	"course-reg/internal/app/repository"
+	"course-reg/internal/pkg/utils"
 	"sync"
+	"time"
+)
+
+const (
+	HoldTTL           = 10 * time.Minute // how long a held seat is kept before it must be confirmed
+	MaxHolds          = 3                // seats a student can hold at once
+	holdSweepInterval = time.Second      // how often the worker releases expired holds
+	maxBatchSize      = 256              // most queued requests handled, and enrollment rows written, in one group commit
+
+	journalFlushInterval = 200 * time.Millisecond // how often journaled enrollments are applied to the DB
 )
 
 type RequestType int
@@ -14,20 +26,62 @@ const (
 	READ_ALL
 	ADMIN_ENROLL
 	ADMIN_CANCEL
+	JOIN_WAITLIST
+	LEAVE_WAITLIST
+	ENROLL_CART
+	SWAP
+	HOLD
+	CONFIRM_HOLD
+	RELEASE_HOLD
+	RELEASE_EXPIRED_HOLDS
+	SET_LIMIT
 )
 
-// EnrollmentWorker handles enrollment operations with cache
+// Overrides are the rules an admin may explicitly skip when force-enrolling a student
+type Overrides struct {
+	IgnoreCapacity     bool
+	IgnoreTimeConflict bool
+	IgnoreLimits       bool
+	BypassEligibility  bool
+	IgnoreCourseGroups bool // exclusive groups are not checked and co-requisites are not enrolled
+}
+
+// EnrollmentWorker handles enrollment operations with cache.
+// Requests are partitioned by student over shards, each a goroutine with its own queue.
+// Plain enrollments and cancellations only touch the student's own courses and the course's seat count,
+// so shards decide them concurrently while holding mu shared. Every other request holds mu exclusively.
+// With one shard every decision is serialized, as in a single worker.
 type EnrollmentWorker struct {
-	wg          sync.WaitGroup
-	queueSize   int
-	requestChan chan EnrollmentRequest
-	cache       *cache.EnrollmentCache
-	enrollRepo  repository.EnrollmentRepositoryInterface
+	wg           sync.WaitGroup
+	mu           sync.RWMutex
+	queueSize    int
+	shards       int
+	batchSize    int
+	requestChans []chan EnrollmentRequest // one queue per shard
+	stopFlusher  chan struct{}
+	cache        *cache.EnrollmentCache
+	enrollRepo   repository.EnrollmentRepositoryInterface
+	journal      *journal.Journal // nil writes enrollments to the DB before acknowledging them
+	clock        utils.TimeProvider
 }
 
-func NewEnrollmentWorker(queueSize int, enrollRepo repository.EnrollmentRepositoryInterface) *EnrollmentWorker {
+// NewEnrollmentWorker creates a worker with shards queues sharing queueSize.
+// With a journal, plain enrollments are acknowledged once journaled and applied to the DB in the background.
+func NewEnrollmentWorker(
+	queueSize, shards int,
+	enrollRepo repository.EnrollmentRepositoryInterface,
+	j *journal.Journal,
+	clock utils.TimeProvider,
+) *EnrollmentWorker {
+	if j != nil {
+		enrollRepo = &flushingRepository{EnrollmentRepositoryInterface: enrollRepo, journal: j}
+	}
 	return &EnrollmentWorker{
 		queueSize:  queueSize,
+		shards:     max(shards, 1),
+		batchSize:  maxBatchSize,
 		enrollRepo: enrollRepo,
+		journal:    j,
+		clock:      clock,
 	}
 }
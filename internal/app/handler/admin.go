@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"course-reg/internal/app/domain/dto"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
 	"course-reg/internal/app/models"
 	"course-reg/internal/app/service"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -19,49 +23,88 @@ func NewAdminHandler(s service.AdminServiceInterface) *AdminHandler {
 }
 
 func (h *AdminHandler) StartRegistration(c *gin.Context) {
-	if err := h.adminService.StartRegistration(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
-		return
-	}
-	c.Status(http.StatusOK)
+	h.changePhase(c, h.adminService.StartRegistration)
 }
 
 func (h *AdminHandler) PauseRegistration(c *gin.Context) {
-	if err := h.adminService.PauseRegistration(); err != nil {
+	h.changePhase(c, h.adminService.PauseRegistration)
+}
+
+func (h *AdminHandler) CloseRegistration(c *gin.Context) {
+	h.changePhase(c, h.adminService.CloseRegistration)
+}
+
+func (h *AdminHandler) FinalizeRegistration(c *gin.Context) {
+	h.changePhase(c, h.adminService.FinalizeRegistration)
+}
+
+func (h *AdminHandler) PrepareRegistration(c *gin.Context) {
+	h.changePhase(c, h.adminService.PrepareRegistration)
+}
+
+func (h *AdminHandler) changePhase(c *gin.Context, change func() error) {
+	if err := change(); err != nil {
+		if status, msg, ok := phaseErrToResponse(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
 		return
 	}
-	c.Status(http.StatusOK)
+	c.JSON(http.StatusOK, gin.H{"phase": h.adminService.GetRegistrationState()})
 }
 
 func (h *AdminHandler) GetRegistrationState(c *gin.Context) {
-	enabled := h.adminService.GetRegistrationState()
-	c.JSON(http.StatusOK, gin.H{"enabled": enabled})
+	phase := h.adminService.GetRegistrationState()
+	c.JSON(http.StatusOK, gin.H{
+		"phase":   phase,
+		"mode":    h.adminService.GetRegistrationMode(),
+		"term_id": h.adminService.GetActiveTerm(),
+		"enabled": phase == registration.PhaseOpen,
+	})
+}
+
+// phaseErrToResponse maps registration phase errors; ok is false for any other error
+func phaseErrToResponse(err error) (status int, msg string, ok bool) {
+	switch {
+	case errors.Is(err, e.ErrAlreadyInPhase):
+		return http.StatusConflict, "이미 해당 단계입니다", true
+	case errors.Is(err, e.ErrIllegalPhaseTransition):
+		return http.StatusConflict, "현재 단계에서는 전환할 수 없는 단계입니다", true
+	case errors.Is(err, e.ErrInvalidRegistrationPhase):
+		return http.StatusConflict, "현재 수강 신청 단계에서는 할 수 없는 작업입니다", true
+	default:
+		return 0, "", false
+	}
 }
 
 func (h *AdminHandler) SetRegistrationPeriod(c *gin.Context) {
-	// var req dto.SetRegistrationPeriodRequest
+	var req dto.SetRegistrationPeriodRequest
 
-	// if err := c.ShouldBindJSON(&req); err != nil {
-	// 	log.Println("set registration schedule failed:", err.Error())
-	// 	c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 수강 신청 기간"})
-	// 	return
-	// }
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("set registration schedule failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 수강 신청 기간"})
+		return
+	}
 
-	// if err := h.adminService.SetRegistrationPeriod(req.StartTime, req.EndTime); err != nil {
-	// 	c.JSON(http.StatusBadRequest, gin.H{"error": ""})
-	// 	return
-	// }
+	if err := h.adminService.SetRegistrationPeriod(req.StartTime, req.EndTime); err != nil {
+		if errors.Is(err, e.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 수강 신청 기간"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
 
-	// c.Status(http.StatusOK)
+	c.Status(http.StatusOK)
 }
 
 func (h *AdminHandler) GetRegistrationPeriod(c *gin.Context) {
-	// startTime, endTime := h.adminService.GetRegistrationPeriod()
-	// c.JSON(http.StatusOK, gin.H{
-	// 	"start_time": startTime,
-	// 	"end_time":   endTime,
-	// })
+	startTime, endTime := h.adminService.GetRegistrationPeriod()
+	c.JSON(http.StatusOK, gin.H{
+		"start_time": startTime,
+		"end_time":   endTime,
+	})
 }
 
 func (h *AdminHandler) RegisterStudents(c *gin.Context) {
@@ -75,6 +118,10 @@ func (h *AdminHandler) RegisterStudents(c *gin.Context) {
 	}
 
 	if err := h.adminService.RegisterStudents(students); err != nil {
+		if status, msg, ok := phaseErrToResponse(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		// todo: 중복된 학생 처리
 		c.JSON(http.StatusBadRequest, gin.H{"error": "학생 리스트 등록 실패"})
 		return
@@ -85,6 +132,10 @@ func (h *AdminHandler) RegisterStudents(c *gin.Context) {
 
 func (h *AdminHandler) ResetStudents(c *gin.Context) {
 	if err := h.adminService.ResetStudents(); err != nil {
+		if status, msg, ok := phaseErrToResponse(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "학생 리스트 삭제 실패, 개발자 호출 필요!"})
 		return
 	}
@@ -103,6 +154,10 @@ func (h *AdminHandler) CreateCourse(c *gin.Context) {
 
 	courseID, err := h.adminService.CreateCourse(course)
 	if err != nil {
+		if status, msg, ok := phaseErrToResponse(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		// todo: 중복된 강의 처리
 		c.JSON(http.StatusBadRequest, gin.H{"error": "강의 등록 실패"})
 		return
@@ -120,7 +175,12 @@ func (h *AdminHandler) DeleteCourse(c *gin.Context) {
 	}
 
 	if err := h.adminService.DeleteCourse(uint(course_id)); err != nil {
+		if status, msg, ok := phaseErrToResponse(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "강의 삭제 실패"})
+		return
 	}
 
 	c.Status(http.StatusOK)
@@ -136,6 +196,10 @@ func (h *AdminHandler) RegisterCourses(c *gin.Context) {
 	}
 
 	if err := h.adminService.RegisterCourses(courses); err != nil {
+		if status, msg, ok := phaseErrToResponse(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "강의 리스트 등록 실패"})
 		return
 	}
@@ -145,6 +209,10 @@ func (h *AdminHandler) RegisterCourses(c *gin.Context) {
 
 func (h *AdminHandler) ResetCourses(c *gin.Context) {
 	if err := h.adminService.ResetCourses(); err != nil {
+		if status, msg, ok := phaseErrToResponse(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "강의 리스트 삭제 실패, 개발자 호출 필요!"})
 		return
 	}
@@ -154,6 +222,10 @@ func (h *AdminHandler) ResetCourses(c *gin.Context) {
 
 func (h *AdminHandler) ResetEnrollments(c *gin.Context) {
 	if err := h.adminService.ResetEnrollments(); err != nil {
+		if status, msg, ok := phaseErrToResponse(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "수강 신청 초기화 실패, 개발자 호출 필요!"})
 		return
 	}
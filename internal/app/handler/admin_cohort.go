@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/dto"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) GetCohorts(c *gin.Context) {
+	cohorts, err := h.adminService.GetCohorts()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, cohorts)
+}
+
+func (h *AdminHandler) SetCohorts(c *gin.Context) {
+	var cohorts []models.Cohort
+
+	if err := c.ShouldBindJSON(&cohorts); err != nil {
+		log.Println("set cohorts failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 입장 그룹 리스트"})
+		return
+	}
+
+	if err := h.adminService.SetCohorts(cohorts); err != nil {
+		status, msg := cohortErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) AssignCohorts(c *gin.Context) {
+	var req dto.AssignCohortsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("assign cohorts failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 입장 그룹 배정"})
+		return
+	}
+
+	if err := h.adminService.AssignCohorts(req.Assignments); err != nil {
+		status, msg := cohortErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) AssignRandomCohorts(c *gin.Context) {
+	var req dto.AssignRandomCohortsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("assign random cohorts failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 입장 그룹 배정"})
+		return
+	}
+
+	assignments, err := h.adminService.AssignRandomCohorts(req.Cohorts, req.Seed)
+	if err != nil {
+		status, msg := cohortErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"assignments": assignments})
+}
+
+func cohortErrToResponse(err error) (int, string) {
+	if status, msg, ok := phaseErrToResponse(err); ok {
+		return status, msg
+	}
+	switch {
+	case errors.Is(err, e.ErrInvalidInput):
+		return http.StatusBadRequest, "잘못된 입장 그룹 설정입니다"
+	case errors.Is(err, e.ErrStudentNotFound):
+		return http.StatusNotFound, "존재하지 않는 학생입니다"
+	default:
+		return http.StatusInternalServerError, "서버 오류"
+	}
+}
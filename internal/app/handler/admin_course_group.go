@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) GetCourseGroups(c *gin.Context) {
+	groups, err := h.adminService.GetCourseGroups()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, groups)
+}
+
+func (h *AdminHandler) CreateCourseGroup(c *gin.Context) {
+	var group models.CourseGroup
+
+	if err := c.ShouldBindJSON(&group); err != nil {
+		log.Println("create course group failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 그룹 형식"})
+		return
+	}
+
+	groupID, err := h.adminService.CreateCourseGroup(&group)
+	if err != nil {
+		status, msg := courseGroupErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"group_id": groupID})
+}
+
+func (h *AdminHandler) DeleteCourseGroup(c *gin.Context) {
+	groupID, err := strconv.Atoi(c.Param("group_id"))
+	if err != nil {
+		log.Println("delete course group failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 그룹 id"})
+		return
+	}
+
+	if err := h.adminService.DeleteCourseGroup(uint(groupID)); err != nil {
+		status, msg := courseGroupErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func courseGroupErrToResponse(err error) (int, string) {
+	if status, msg, ok := phaseErrToResponse(err); ok {
+		return status, msg
+	}
+	switch {
+	case errors.Is(err, e.ErrInvalidInput):
+		return http.StatusBadRequest, "잘못된 강의 그룹 설정입니다"
+	case errors.Is(err, e.ErrCourseNotFound):
+		return http.StatusNotFound, "존재하지 않는 강의가 포함되어 있습니다"
+	case errors.Is(err, e.ErrCourseGroupNotFound):
+		return http.StatusNotFound, "존재하지 않는 강의 그룹입니다"
+	default:
+		return http.StatusInternalServerError, "서버 오류"
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/dto"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) GetEligibilityRules(c *gin.Context) {
+	rules, err := h.adminService.GetEligibilityRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *AdminHandler) CreateEligibilityRule(c *gin.Context) {
+	var rule models.EligibilityRule
+
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		log.Println("create eligibility rule failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 수강 자격 조건 형식"})
+		return
+	}
+
+	ruleID, err := h.adminService.CreateEligibilityRule(&rule)
+	if err != nil {
+		status, msg := eligibilityErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rule_id": ruleID})
+}
+
+func (h *AdminHandler) DeleteEligibilityRule(c *gin.Context) {
+	ruleID, err := strconv.Atoi(c.Param("rule_id"))
+	if err != nil {
+		log.Println("delete eligibility rule failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 수강 자격 조건 id"})
+		return
+	}
+
+	if err := h.adminService.DeleteEligibilityRule(uint(ruleID)); err != nil {
+		status, msg := eligibilityErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) GetStudentGroups(c *gin.Context) {
+	groups, err := h.adminService.GetStudentGroups()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, groups)
+}
+
+func (h *AdminHandler) SetStudentGroup(c *gin.Context) {
+	var req dto.SetStudentGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("set student group failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학생 리스트"})
+		return
+	}
+
+	if err := h.adminService.SetStudentGroup(c.Param("group_name"), req.StudentIDs); err != nil {
+		status, msg := eligibilityErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) ImportCompletions(c *gin.Context) {
+	var completions []models.CourseCompletion
+
+	if err := c.ShouldBindJSON(&completions); err != nil {
+		log.Println("import completions failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 이수 내역 리스트"})
+		return
+	}
+
+	if err := h.adminService.ImportCompletions(completions); err != nil {
+		status, msg := eligibilityErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func eligibilityErrToResponse(err error) (int, string) {
+	if status, msg, ok := phaseErrToResponse(err); ok {
+		return status, msg
+	}
+	switch {
+	case errors.Is(err, e.ErrInvalidInput):
+		return http.StatusBadRequest, "잘못된 수강 자격 설정입니다"
+	case errors.Is(err, e.ErrCourseNotFound):
+		return http.StatusNotFound, "존재하지 않는 강의입니다"
+	case errors.Is(err, e.ErrEligibilityRuleNotFound):
+		return http.StatusNotFound, "존재하지 않는 수강 자격 조건입니다"
+	default:
+		return http.StatusInternalServerError, "서버 오류"
+	}
+}
+
+func (h *AdminHandler) RecordCompletionsFromEnrollments(c *gin.Context) {
+	count, err := h.adminService.RecordCompletionsFromEnrollments()
+	if err != nil {
+		status, msg := eligibilityErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recorded": count})
+}
+
+func (h *AdminHandler) GetPrerequisites(c *gin.Context) {
+	prerequisites, err := h.adminService.GetPrerequisites()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, prerequisites)
+}
+
+func (h *AdminHandler) SetPrerequisites(c *gin.Context) {
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		log.Println("set prerequisites failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	var req dto.SetPrerequisitesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("set prerequisites failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 선수 과목 리스트"})
+		return
+	}
+
+	if err := h.adminService.SetPrerequisites(uint(courseID), req.PrerequisiteIDs); err != nil {
+		status, msg := eligibilityErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
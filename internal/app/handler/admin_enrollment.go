@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/dto"
+	"course-reg/internal/app/domain/worker"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) ForceEnroll(c *gin.Context) {
+	var req dto.ForceEnrollRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("force enroll failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 수강 등록 요청 (사유 필수)"})
+		return
+	}
+
+	overrides := worker.Overrides{
+		IgnoreCapacity:     req.IgnoreCapacity,
+		IgnoreTimeConflict: req.IgnoreTimeConflict,
+		IgnoreLimits:       req.IgnoreLimits,
+		BypassEligibility:  req.BypassEligibility,
+		IgnoreCourseGroups: req.IgnoreCourseGroups,
+	}
+	if err := h.adminService.ForceEnroll(c.Request.Context(), req.StudentID, req.CourseID, overrides, req.Reason); err != nil {
+		status, msg := adminEnrollErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "수강 등록 성공"})
+}
+
+func (h *AdminHandler) ForceCancel(c *gin.Context) {
+	var req dto.ForceCancelRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("force cancel failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 수강 취소 요청 (사유 필수)"})
+		return
+	}
+
+	if err := h.adminService.ForceCancel(c.Request.Context(), req.StudentID, req.CourseID, req.Reason); err != nil {
+		status, msg := adminEnrollErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "수강 취소 성공"})
+}
+
+func (h *AdminHandler) GetAdminEnrollmentLogs(c *gin.Context) {
+	logs, err := h.adminService.GetAdminEnrollmentLogs()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, logs)
+}
+
+func adminEnrollErrToResponse(err error) (int, string) {
+	if status, msg, ok := phaseErrToResponse(err); ok {
+		return status, msg
+	}
+	return enrollErrToResponse(err)
+}
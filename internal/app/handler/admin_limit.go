@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/dto"
+	"course-reg/internal/app/domain/e"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) GetLimits(c *gin.Context) {
+	defaultLimit, overrides, err := h.adminService.GetLimits()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"max_courses": defaultLimit.MaxCourses,
+		"max_credits": defaultLimit.MaxCredits,
+		"students":    overrides,
+	})
+}
+
+func (h *AdminHandler) SetDefaultLimit(c *gin.Context) {
+	var req dto.LimitRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("set default limit failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 신청 제한"})
+		return
+	}
+
+	if err := h.adminService.SetDefaultLimit(cache.Limit{MaxCourses: req.MaxCourses, MaxCredits: req.MaxCredits}); err != nil {
+		status, msg := limitErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) SetStudentLimit(c *gin.Context) {
+	studentID, err := strconv.Atoi(c.Param("student_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학생 ID"})
+		return
+	}
+
+	var req dto.LimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("set student limit failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 신청 제한"})
+		return
+	}
+
+	if err := h.adminService.SetStudentLimit(uint(studentID), cache.Limit{MaxCourses: req.MaxCourses, MaxCredits: req.MaxCredits}); err != nil {
+		status, msg := limitErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) DeleteStudentLimit(c *gin.Context) {
+	studentID, err := strconv.Atoi(c.Param("student_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학생 ID"})
+		return
+	}
+
+	if err := h.adminService.DeleteStudentLimit(uint(studentID)); err != nil {
+		status, msg := limitErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func limitErrToResponse(err error) (int, string) {
+	if status, msg, ok := phaseErrToResponse(err); ok {
+		return status, msg
+	}
+	switch {
+	case errors.Is(err, e.ErrInvalidInput):
+		return http.StatusBadRequest, "잘못된 신청 제한입니다"
+	case errors.Is(err, e.ErrServerBusy):
+		return http.StatusServiceUnavailable, "요청이 많아 처리하지 못했습니다. 잠시 후 다시 시도해 주세요"
+	default:
+		return http.StatusInternalServerError, "서버 오류"
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/dto"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) SetRegistrationMode(c *gin.Context) {
+	var req dto.SetRegistrationModeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("set registration mode failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 신청 방식"})
+		return
+	}
+
+	if err := h.adminService.SetRegistrationMode(registration.Mode(req.Mode)); err != nil {
+		status, msg := allocationErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"mode": h.adminService.GetRegistrationMode()})
+}
+
+func (h *AdminHandler) DrawLottery(c *gin.Context) {
+	var req dto.DrawLotteryRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("draw lottery failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 추첨 요청"})
+		return
+	}
+
+	run, err := h.adminService.DrawLottery(req.Seed, req.WaitlistLosers)
+	if err != nil {
+		status, msg := allocationErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (h *AdminHandler) GetLotteryRuns(c *gin.Context) {
+	runs, err := h.adminService.GetLotteryRuns()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, runs)
+}
+
+func (h *AdminHandler) GetLotteryResults(c *gin.Context) {
+	runID, err := strconv.Atoi(c.Param("run_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 추첨 ID"})
+		return
+	}
+
+	results, err := h.adminService.GetLotteryResults(uint(runID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+func allocationErrToResponse(err error) (int, string) {
+	if status, msg, ok := phaseErrToResponse(err); ok {
+		return status, msg
+	}
+	switch {
+	case errors.Is(err, e.ErrInvalidInput):
+		return http.StatusBadRequest, "잘못된 신청 방식입니다"
+	case errors.Is(err, e.ErrWrongRegistrationMode):
+		return http.StatusConflict, "현재 신청 방식에서는 할 수 없는 작업입니다"
+	case errors.Is(err, e.ErrAssignmentChanged):
+		return http.StatusConflict, "미리보기 이후 배정 결과가 바뀌었습니다. 다시 미리보기 해주세요"
+	default:
+		return http.StatusInternalServerError, "서버 오류"
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/dto"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) PreviewPreferenceAssignment(c *gin.Context) {
+	var req dto.PreferenceAssignmentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("preview preference assignment failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 배정 요청"})
+		return
+	}
+
+	result, err := h.adminService.PreviewPreferenceAssignment(req.Seed)
+	if err != nil {
+		status, msg := allocationErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *AdminHandler) CommitPreferenceAssignment(c *gin.Context) {
+	var req dto.PreferenceAssignmentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil || req.Seed == nil || req.Checksum == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "미리보기의 seed와 checksum이 필요합니다"})
+		return
+	}
+
+	result, err := h.adminService.CommitPreferenceAssignment(*req.Seed, req.Checksum)
+	if err != nil {
+		status, msg := allocationErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/dto"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) GetRounds(c *gin.Context) {
+	rounds, err := h.adminService.GetRounds()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, rounds)
+}
+
+func (h *AdminHandler) CreateRound(c *gin.Context) {
+	var round models.RegistrationRound
+
+	if err := c.ShouldBindJSON(&round); err != nil {
+		log.Println("create round failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 신청 차수 형식"})
+		return
+	}
+
+	roundID, err := h.adminService.CreateRound(&round)
+	if err != nil {
+		status, msg := roundErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"round_id": roundID})
+}
+
+func (h *AdminHandler) UpdateRound(c *gin.Context) {
+	roundID, err := strconv.Atoi(c.Param("round_id"))
+	if err != nil {
+		log.Println("update round failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 신청 차수 id"})
+		return
+	}
+
+	var round models.RegistrationRound
+	if err := c.ShouldBindJSON(&round); err != nil {
+		log.Println("update round failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 신청 차수 형식"})
+		return
+	}
+	round.ID = uint(roundID)
+
+	if err := h.adminService.UpdateRound(&round); err != nil {
+		status, msg := roundErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) DeleteRound(c *gin.Context) {
+	roundID, err := strconv.Atoi(c.Param("round_id"))
+	if err != nil {
+		log.Println("delete round failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 신청 차수 id"})
+		return
+	}
+
+	if err := h.adminService.DeleteRound(uint(roundID)); err != nil {
+		status, msg := roundErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) SetRoundStudents(c *gin.Context) {
+	roundID, err := strconv.Atoi(c.Param("round_id"))
+	if err != nil {
+		log.Println("set round students failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 신청 차수 id"})
+		return
+	}
+
+	var req dto.SetRoundStudentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("set round students failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학생 리스트"})
+		return
+	}
+
+	if err := h.adminService.SetRoundStudents(uint(roundID), req.StudentIDs); err != nil {
+		status, msg := roundErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func roundErrToResponse(err error) (int, string) {
+	if status, msg, ok := phaseErrToResponse(err); ok {
+		return status, msg
+	}
+	switch {
+	case errors.Is(err, e.ErrInvalidInput):
+		return http.StatusBadRequest, "잘못된 신청 차수 설정입니다"
+	case errors.Is(err, e.ErrRoundNotFound):
+		return http.StatusNotFound, "존재하지 않는 신청 차수입니다"
+	default:
+		return http.StatusInternalServerError, "서버 오류"
+	}
+}
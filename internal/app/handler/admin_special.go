@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/dto"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) GetSpecialCourseRules(c *gin.Context) {
+	maxSpecialCourses, rules, err := h.adminService.GetSpecialCourseRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"max_special_courses": maxSpecialCourses, "rules": rules})
+}
+
+func (h *AdminHandler) SetSpecialCourseLimit(c *gin.Context) {
+	var req dto.SpecialCourseLimitRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("set special course limit failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 특별 강의 제한"})
+		return
+	}
+
+	if err := h.adminService.SetSpecialCourseLimit(req.MaxSpecialCourses); err != nil {
+		status, msg := specialErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) SetSpecialCourseRule(c *gin.Context) {
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	var req dto.SpecialCourseRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("set special course rule failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 수강 대상 설정"})
+		return
+	}
+
+	rule := &models.SpecialCourseRule{CourseID: uint(courseID), StudentIDs: req.StudentIDs, Cohorts: req.Cohorts}
+	if err := h.adminService.SetSpecialCourseRule(rule); err != nil {
+		status, msg := specialErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *AdminHandler) DeleteSpecialCourseRule(c *gin.Context) {
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	if err := h.adminService.DeleteSpecialCourseRule(uint(courseID)); err != nil {
+		status, msg := specialErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func specialErrToResponse(err error) (int, string) {
+	if status, msg, ok := phaseErrToResponse(err); ok {
+		return status, msg
+	}
+	switch {
+	case errors.Is(err, e.ErrCourseNotFound):
+		return http.StatusNotFound, "존재하지 않는 강의입니다"
+	case errors.Is(err, e.ErrInvalidInput):
+		return http.StatusBadRequest, "특별 강의가 아니거나 잘못된 설정입니다"
+	default:
+		return http.StatusInternalServerError, "서버 오류"
+	}
+}
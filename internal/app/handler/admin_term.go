@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/dto"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/rollover"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *AdminHandler) GetTerms(c *gin.Context) {
+	terms, err := h.adminService.GetTerms()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+	c.JSON(http.StatusOK, terms)
+}
+
+func (h *AdminHandler) CreateTerm(c *gin.Context) {
+	var req dto.CreateTermRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("create term failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학기 형식"})
+		return
+	}
+
+	termID, err := h.adminService.CreateTerm(req.Name)
+	if err != nil {
+		status, msg := termErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"term_id": termID})
+}
+
+func (h *AdminHandler) ActivateTerm(c *gin.Context) {
+	var req dto.ActivateTermRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("activate term failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학기 id"})
+		return
+	}
+
+	if err := h.adminService.ActivateTerm(req.TermID); err != nil {
+		status, msg := termErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"term_id": req.TermID, "phase": h.adminService.GetRegistrationState()})
+}
+
+func (h *AdminHandler) PreviewTermRollover(c *gin.Context) {
+	var opts rollover.Options
+
+	if err := c.ShouldBindJSON(&opts); err != nil {
+		log.Println("preview term rollover failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학기 이월 형식"})
+		return
+	}
+
+	plan, err := h.adminService.PreviewTermRollover(opts)
+	if err != nil {
+		status, msg := termErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+func (h *AdminHandler) RolloverTerm(c *gin.Context) {
+	var opts rollover.Options
+
+	if err := c.ShouldBindJSON(&opts); err != nil {
+		log.Println("rollover term failed:", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학기 이월 형식"})
+		return
+	}
+
+	termID, plan, err := h.adminService.RolloverTerm(opts)
+	if err != nil {
+		status, msg := termErrToResponse(err)
+		if plan != nil {
+			c.JSON(status, gin.H{"error": msg, "problems": plan.Problems})
+			return
+		}
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"term_id": termID, "courses": len(plan.Courses), "students": len(plan.StudentIDs)})
+}
+
+func (h *AdminHandler) GetTermCourses(c *gin.Context) {
+	termID, err := strconv.Atoi(c.Param("term_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학기 id"})
+		return
+	}
+
+	courses, err := h.adminService.GetTermCourses(uint(termID))
+	if err != nil {
+		status, msg := termErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+	c.JSON(http.StatusOK, courses)
+}
+
+func (h *AdminHandler) GetTermEnrollments(c *gin.Context) {
+	termID, err := strconv.Atoi(c.Param("term_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 학기 id"})
+		return
+	}
+
+	enrollments, err := h.adminService.GetTermEnrollments(uint(termID))
+	if err != nil {
+		status, msg := termErrToResponse(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+	c.JSON(http.StatusOK, enrollments)
+}
+
+func termErrToResponse(err error) (int, string) {
+	if errors.Is(err, e.ErrInvalidRegistrationPhase) {
+		return http.StatusConflict, "수강 신청이 진행 중인 동안에는 학기를 바꿀 수 없습니다"
+	}
+	if errors.Is(err, e.ErrIllegalPhaseTransition) {
+		return http.StatusConflict, "수강 신청이 진행 중이던 학기로는 바꿀 수 없습니다"
+	}
+	switch {
+	case errors.Is(err, e.ErrInvalidInput):
+		return http.StatusBadRequest, "잘못된 학기 이름입니다"
+	case errors.Is(err, e.ErrRolloverProblems):
+		return http.StatusUnprocessableEntity, "이월할 강의 정보에 문제가 있습니다"
+	case errors.Is(err, e.ErrDuplicateTerm):
+		return http.StatusConflict, "이미 존재하는 학기 이름입니다"
+	case errors.Is(err, e.ErrTermNotFound):
+		return http.StatusNotFound, "존재하지 않는 학기입니다"
+	default:
+		return http.StatusInternalServerError, "서버 오류"
+	}
+}
@@ -3,10 +3,14 @@ package handler
 import (
 	"course-reg/internal/app/domain/dto"
 	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/eligibility"
+	"course-reg/internal/app/domain/registration"
 	"course-reg/internal/app/service"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -33,18 +37,39 @@ func (h *CourseRegHandler) EnrollCourse(c *gin.Context) {
 		return
 	}
 
-	err := h.courseRegService.Enroll(studentID, req.CourseID)
+	err := h.courseRegService.Enroll(c.Request.Context(), studentID, req.CourseID, c.GetHeader(idempotencyKeyHeader))
 	if err != nil {
-		status, msg := enrollErrToResponse(err)
-		c.JSON(status, gin.H{"error": msg})
+		respondEnrollErr(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "수강신청 성공"})
 }
 
+const (
+	// busyRetryAfter is the Retry-After sent when the enrollment queue is full
+	busyRetryAfter = "1"
+	// idempotencyKeyHeader lets clients retry enroll, cancel and swap safely; a repeated key replays the first outcome
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+// respondEnrollErr writes an enrollment error, telling the client when to retry if the worker is busy
+func respondEnrollErr(c *gin.Context, err error) {
+	status, msg := enrollErrToResponse(err)
+	if errors.Is(err, e.ErrServerBusy) {
+		c.Header("Retry-After", busyRetryAfter)
+	}
+	c.JSON(status, gin.H{"error": msg})
+}
+
 func enrollErrToResponse(err error) (int, string) {
+	var entryErr *registration.EntryTimeError
+	var notEligibleErr *eligibility.NotEligibleError
 	switch {
+	case errors.As(err, &entryErr):
+		return http.StatusForbidden, fmt.Sprintf("아직 입장 시간이 아닙니다 (%s부터 신청 가능)", entryErr.EntryTime.Format("01-02 15:04"))
+	case errors.Is(err, e.ErrCorequisiteFailed): // wraps the co-requisite's own error, so it must come first
+		return http.StatusConflict, "함께 신청해야 하는 강의를 신청할 수 없습니다"
 	case errors.Is(err, e.ErrCourseNotFound):
 		return http.StatusNotFound, "존재하지 않는 강의입니다"
 	case errors.Is(err, e.ErrStudentNotFound):
@@ -53,13 +78,80 @@ func enrollErrToResponse(err error) (int, string) {
 		return http.StatusConflict, "시간이 겹치는 강의가 있습니다"
 	case errors.Is(err, e.ErrAlreadyEnrolled):
 		return http.StatusConflict, "이미 신청한 강의입니다"
+	case errors.Is(err, e.ErrNotEnrolled):
+		return http.StatusConflict, "신청하지 않은 강의입니다"
 	case errors.Is(err, e.ErrCourseFull):
 		return http.StatusConflict, "정원이 초과되었습니다"
+	case errors.Is(err, e.ErrCourseLimitExceeded):
+		return http.StatusConflict, "신청 가능한 최대 강의 수를 초과했습니다"
+	case errors.Is(err, e.ErrCreditLimitExceeded):
+		return http.StatusConflict, "신청 가능한 최대 학점을 초과했습니다"
+	case errors.Is(err, e.ErrSpecialLimitExceeded):
+		return http.StatusConflict, "신청 가능한 특별 강의 수를 초과했습니다"
+	case errors.Is(err, e.ErrPrerequisiteNotMet):
+		return http.StatusForbidden, "선수 과목을 이수하지 않았습니다"
+	case errors.As(err, &notEligibleErr):
+		return http.StatusForbidden, fmt.Sprintf("수강 대상이 아닌 강의입니다 (%s)", notEligibleErr.Reason)
+	case errors.Is(err, e.ErrNotEligibleForCourse):
+		return http.StatusForbidden, "수강 대상이 아닌 강의입니다"
+	case errors.Is(err, e.ErrExclusiveCourseConflict):
+		return http.StatusConflict, "같은 그룹의 다른 강의를 이미 신청했습니다"
+	case errors.Is(err, e.ErrCorequisiteUnsupported):
+		return http.StatusConflict, "함께 신청해야 하는 강의가 있는 강의는 할 수 없는 작업입니다"
+	case errors.Is(err, e.ErrCourseNotFull):
+		return http.StatusConflict, "아직 여석이 있는 강의입니다"
+	case errors.Is(err, e.ErrAlreadyWaitlisted):
+		return http.StatusConflict, "이미 대기 신청한 강의입니다"
+	case errors.Is(err, e.ErrNotWaitlisted):
+		return http.StatusConflict, "대기 신청하지 않은 강의입니다"
+	case errors.Is(err, e.ErrWaitlistFull):
+		return http.StatusConflict, "대기 인원이 마감되었습니다"
+	case errors.Is(err, e.ErrSeatAlreadyHeld):
+		return http.StatusConflict, "이미 자리를 확보한 강의입니다"
+	case errors.Is(err, e.ErrNoSeatHold):
+		return http.StatusConflict, "확보한 자리가 없는 강의입니다"
+	case errors.Is(err, e.ErrHoldExpired):
+		return http.StatusConflict, "자리 확보 시간이 만료되었습니다"
+	case errors.Is(err, e.ErrHoldConflict):
+		return http.StatusConflict, "자리를 확보한 다른 강의와 겹칩니다"
+	case errors.Is(err, e.ErrTooManyHolds):
+		return http.StatusConflict, "동시에 확보할 수 있는 자리 수를 초과했습니다"
+	case errors.Is(err, e.ErrWrongRegistrationMode):
+		return http.StatusForbidden, "현재 신청 방식에서는 할 수 없는 작업입니다"
+	case errors.Is(err, e.ErrAlreadyApplied):
+		return http.StatusConflict, "이미 추첨 신청한 강의입니다"
+	case errors.Is(err, e.ErrNotApplied):
+		return http.StatusConflict, "추첨 신청하지 않은 강의입니다"
+	case errors.Is(err, e.ErrAlreadyInCart):
+		return http.StatusConflict, "이미 장바구니에 담은 강의입니다"
+	case errors.Is(err, e.ErrNotInCart):
+		return http.StatusConflict, "장바구니에 없는 강의입니다"
+	case errors.Is(err, e.ErrCartFull):
+		return http.StatusConflict, "장바구니가 가득 찼습니다"
+	case errors.Is(err, e.ErrCartEmpty):
+		return http.StatusBadRequest, "장바구니가 비어 있습니다"
+	case errors.Is(err, e.ErrCartRejected):
+		return http.StatusConflict, "장바구니의 다른 강의가 신청되지 않아 함께 취소되었습니다"
+	case errors.Is(err, e.ErrNoPreference):
+		return http.StatusNotFound, "제출한 희망 순위가 없습니다"
+	case errors.Is(err, e.ErrInvalidInput):
+		return http.StatusBadRequest, "잘못된 요청입니다"
+	case errors.Is(err, e.ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity, "다른 요청에 이미 사용된 Idempotency-Key입니다"
+	case errors.Is(err, e.ErrServerBusy):
+		return http.StatusServiceUnavailable, "요청이 많아 처리하지 못했습니다. 잠시 후 다시 시도해 주세요"
+	case errors.Is(err, e.ErrRequestAbandoned):
+		// the worker may still have processed it, so the client has to check the result
+		return http.StatusGatewayTimeout, "요청 처리 시간이 초과되었습니다. 신청 내역을 확인해 주세요"
 	case errors.Is(err, e.ErrEnrollmentDBFailed):
 		log.Println("[error] enrollment DB insert failed:", err)
 		return http.StatusInternalServerError, "수강신청 처리 중 오류가 발생했습니다"
-	case errors.Is(err, e.ErrInvalidRegistrationPeriod):
+	case errors.Is(err, e.ErrInvalidRegistrationPeriod), errors.Is(err, e.ErrInvalidRegistrationPhase):
 		return http.StatusForbidden, "수강신청 기간이 아닙니다"
+	case errors.Is(err, e.ErrNotEligibleForRound):
+		return http.StatusForbidden, "이번 차수의 수강신청 대상이 아닙니다"
+	case errors.Is(err, e.ErrOperationNotAllowed):
+		return http.StatusForbidden, "이번 차수에서는 할 수 없는 작업입니다"
 	default:
 		log.Println("[error] enroll unexpected error:", err.Error())
 		return http.StatusInternalServerError, "알 수 없는 오류가 발생했습니다"
@@ -76,24 +168,206 @@ func (h *CourseRegHandler) GetAllCourseStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
-// func (h *CourseRegHandler) CancelEnrollment(c *gin.Context) {
-// 	studentID := uint(1)
+func (h *CourseRegHandler) CancelEnrollment(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 수강 취소가 가능합니다"})
+		return
+	}
+
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		log.Println("[error] cancel enrollment :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	if err := h.courseRegService.CancelEnrollment(c.Request.Context(), studentID, uint(courseID), c.GetHeader(idempotencyKeyHeader)); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "수강취소 성공"})
+}
+
+func (h *CourseRegHandler) SwapCourse(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 강의 변경이 가능합니다"})
+		return
+	}
+
+	var req dto.SwapCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("[error] swap course :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 변경 요청"})
+		return
+	}
+
+	if err := h.courseRegService.SwapEnrollment(c.Request.Context(), studentID, req.FromCourseID, req.ToCourseID, c.GetHeader(idempotencyKeyHeader)); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "강의 변경 성공"})
+}
+
+func (h *CourseRegHandler) AddToWaitlist(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 대기 신청이 가능합니다"})
+		return
+	}
+
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		log.Println("[error] add to waitlist :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	position, err := h.courseRegService.JoinWaitlist(c.Request.Context(), studentID, uint(courseID))
+	if err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "대기 신청 성공", "waitlist_position": position})
+}
+
+func (h *CourseRegHandler) DeleteFromWaitlist(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 대기 취소가 가능합니다"})
+		return
+	}
+
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		log.Println("[error] delete from waitlist :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	if err := h.courseRegService.LeaveWaitlist(c.Request.Context(), studentID, uint(courseID)); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "대기 취소 성공"})
+}
+
+func (h *CourseRegHandler) GetApplications(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 조회가 가능합니다"})
+		return
+	}
+
+	applications, err := h.courseRegService.GetApplications(studentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+
+	c.JSON(http.StatusOK, applications)
+}
+
+func (h *CourseRegHandler) ApplyCourse(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 추첨 신청이 가능합니다"})
+		return
+	}
+
+	var req dto.EnrollCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("[error] apply course :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 추첨 신청 요청"})
+		return
+	}
+
+	if err := h.courseRegService.Apply(studentID, req.CourseID); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "추첨 신청 성공"})
+}
+
+func (h *CourseRegHandler) WithdrawApplication(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 추첨 신청 취소가 가능합니다"})
+		return
+	}
 
-// 	courseID, err := strconv.Atoi(c.Param("course_id"))
-// 	if err != nil {
-// 		log.Println("cancel enrollment failed:", err.Error())
-// 		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
-// 		return
-// 	}
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		log.Println("[error] withdraw application :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	if err := h.courseRegService.WithdrawApplication(studentID, uint(courseID)); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "추첨 신청 취소 성공"})
+}
 
-// 	success, message, allSeats := h.courseRegService.CancelEnrollment(studentID, uint(courseID))
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success":         success,
-// 		"message":         message,
-// 		"remaining_seats": allSeats,
-// 	})
-// }
+func (h *CourseRegHandler) GetPreference(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 조회가 가능합니다"})
+		return
+	}
+
+	preference, err := h.courseRegService.GetPreference(studentID)
+	if err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preference)
+}
+
+func (h *CourseRegHandler) SubmitPreference(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 희망 순위 제출이 가능합니다"})
+		return
+	}
+
+	var req dto.SubmitPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("[error] submit preference :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 희망 순위 요청"})
+		return
+	}
+
+	if err := h.courseRegService.SubmitPreference(studentID, req.CourseIDs, req.MaxCourses); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "희망 순위 제출 성공"})
+}
+
+func (h *CourseRegHandler) WithdrawPreference(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 희망 순위 취소가 가능합니다"})
+		return
+	}
+
+	if err := h.courseRegService.WithdrawPreference(studentID); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "희망 순위 취소 성공"})
+}
 
-// ForceEnrollCourse
-// ForceCancelCourse
 // GetStatus
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"course-reg/internal/app/domain/dto"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *CourseRegHandler) GetCart(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 조회가 가능합니다"})
+		return
+	}
+
+	items, err := h.courseRegService.GetCart(studentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "서버 오류"})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func (h *CourseRegHandler) AddToCart(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 장바구니를 사용할 수 있습니다"})
+		return
+	}
+
+	var req dto.EnrollCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("[error] add to cart :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 장바구니 요청"})
+		return
+	}
+
+	if err := h.courseRegService.AddToCart(studentID, req.CourseID); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "장바구니 담기 성공"})
+}
+
+func (h *CourseRegHandler) RemoveFromCart(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 장바구니를 사용할 수 있습니다"})
+		return
+	}
+
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		log.Println("[error] remove from cart :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	if err := h.courseRegService.RemoveFromCart(studentID, uint(courseID)); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "장바구니 삭제 성공"})
+}
+
+// SubmitCart enrolls the whole cart at once and reports the result of each course
+func (h *CourseRegHandler) SubmitCart(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 수강 신청이 가능합니다"})
+		return
+	}
+
+	var req dto.SubmitCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println("[error] submit cart :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 장바구니 신청 요청"})
+		return
+	}
+
+	results, err := h.courseRegService.SubmitCart(c.Request.Context(), studentID, req.AllOrNothing)
+	if err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	response := make([]dto.CartCourseResult, len(results))
+	for i, result := range results {
+		response[i] = dto.CartCourseResult{CourseID: result.CourseID, Success: result.Err == nil}
+		if result.Err != nil {
+			_, response[i].Error = enrollErrToResponse(result.Err)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"results": response})
+}
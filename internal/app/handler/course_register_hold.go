@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *CourseRegHandler) HoldSeat(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 자리 확보가 가능합니다"})
+		return
+	}
+
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		log.Println("[error] hold seat :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	expiresAt, err := h.courseRegService.HoldSeat(c.Request.Context(), studentID, uint(courseID))
+	if err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "자리 확보 성공", "expires_at": expiresAt})
+}
+
+func (h *CourseRegHandler) ConfirmHold(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 수강 신청이 가능합니다"})
+		return
+	}
+
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		log.Println("[error] confirm hold :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	if err := h.courseRegService.ConfirmHold(c.Request.Context(), studentID, uint(courseID)); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "수강신청 성공"})
+}
+
+func (h *CourseRegHandler) ReleaseHold(c *gin.Context) {
+	studentID, ok := c.MustGet("studentID").(uint)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "학생만 자리 반납이 가능합니다"})
+		return
+	}
+
+	courseID, err := strconv.Atoi(c.Param("course_id"))
+	if err != nil {
+		log.Println("[error] release hold :", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 강의 ID"})
+		return
+	}
+
+	if err := h.courseRegService.ReleaseHold(c.Request.Context(), studentID, uint(courseID)); err != nil {
+		respondEnrollErr(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "자리 반납 성공"})
+}
@@ -10,7 +10,7 @@ func CORS() gin.HandlerFunc {
 		cors.Config{
 			AllowOrigins:     []string{"http://localhost:3001"},
 			AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-			AllowHeaders:     []string{"Origin", "Content-Type", "Accept"},
+			AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Idempotency-Key"},
 			AllowCredentials: true,
 		},
 	)
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AdminEnrollmentLog records every admin force-enroll and force-cancel with the overrides used and the reason given
+type AdminEnrollmentLog struct {
+	ID                 uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Action             string    `gorm:"type:text;not null" json:"action"` // "ENROLL" or "CANCEL"
+	StudentID          uint      `gorm:"not null;index" json:"student_id"`
+	CourseID           uint      `gorm:"not null" json:"course_id"`
+	IgnoreCapacity     bool      `gorm:"not null" json:"ignore_capacity"`
+	IgnoreTimeConflict bool      `gorm:"not null" json:"ignore_time_conflict"`
+	IgnoreLimits       bool      `gorm:"not null;default:false" json:"ignore_limits"`
+	BypassEligibility  bool      `gorm:"not null" json:"bypass_eligibility"`
+	IgnoreCourseGroups bool      `gorm:"not null;default:false" json:"ignore_course_groups"`
+	Reason             string    `gorm:"type:text;not null" json:"reason"`
+	Error              string    `gorm:"type:text" json:"error,omitempty"` // empty on success
+	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
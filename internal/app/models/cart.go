@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// CartItem is a course a student plans to enroll in when registration opens
+type CartItem struct {
+	TermID    uint      `gorm:"primaryKey;default:0" json:"-"`
+	StudentID uint      `gorm:"primaryKey" json:"-"`
+	CourseID  uint      `gorm:"primaryKey" json:"course_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
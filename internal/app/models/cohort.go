@@ -0,0 +1,7 @@
+package models
+
+// Cohort groups students that enter registration at the same offset from the window start
+type Cohort struct {
+	Name               string `gorm:"primaryKey" json:"name" binding:"required"`
+	EntryOffsetMinutes int    `gorm:"not null;default:0" json:"entry_offset_minutes"`
+}
@@ -2,10 +2,13 @@ package models
 
 type Course struct {
 	ID          uint   `gorm:"primaryKey;autoIncrement"` // `gorm:"primaryKey;autoIncrement" json:"-"`
-	Name        string `gorm:"unique;not null" json:"name" binding:"required"`
+	TermID      uint   `gorm:"not null;default:0;uniqueIndex:idx_course_term_name" json:"term_id"`
+	Name        string `gorm:"not null;uniqueIndex:idx_course_term_name" json:"name" binding:"required"`
+	Code        string `gorm:"not null;default:'';index" json:"code"` // same course across terms; the name if not given
 	Instructor  string `gorm:"not null" json:"instructor" binding:"required"`
 	Description string `gorm:"type:text" json:"description"`
 	Schedules   string `gorm:"type:text;not null" json:"schedules" binding:"required"`
 	Capacity    int    `gorm:"not null" json:"capacity" binding:"required"`
 	IsSpecial   bool   `gorm:"default:false" json:"is_special"`
+	Credits     int    `gorm:"not null;default:0" json:"credits"`
 }
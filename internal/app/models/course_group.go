@@ -0,0 +1,10 @@
+package models
+
+// CourseGroup ties courses together: a student can take at most one course of an EXCLUSIVE group
+// (e.g. levels of the same subject), and the courses of a COREQUISITE group only together (e.g. lecture + lab).
+type CourseGroup struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string `gorm:"not null" json:"name" binding:"required"`
+	Type      string `gorm:"type:text;not null" json:"type" binding:"required"` // EXCLUSIVE, COREQUISITE
+	CourseIDs []uint `gorm:"type:text;serializer:json;not null" json:"course_ids" binding:"required"`
+}
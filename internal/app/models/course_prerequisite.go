@@ -0,0 +1,8 @@
+package models
+
+// CoursePrerequisite requires completing the course PrerequisiteCode in a previous term before enrolling in
+// a course CourseCode. Both are course codes, so prerequisites carry over to later terms.
+type CoursePrerequisite struct {
+	CourseCode       string `gorm:"primaryKey" json:"course_code"`
+	PrerequisiteCode string `gorm:"primaryKey" json:"prerequisite_code"`
+}
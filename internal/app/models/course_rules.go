@@ -0,0 +1,9 @@
+package models
+
+// CourseRules are the rules that refer to courses by ID, and so belong to the term of those courses.
+// It is not a table.
+type CourseRules struct {
+	CourseGroups     []CourseGroup       `json:"course_groups"`
+	SpecialRules     []SpecialCourseRule `json:"special_rules"`
+	EligibilityRules []EligibilityRule   `json:"eligibility_rules"`
+}
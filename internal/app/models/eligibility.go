@@ -0,0 +1,24 @@
+package models
+
+// EligibilityRule is one condition a student must meet to enroll in a course; a course is open to
+// students meeting all of its rules. Which fields are used depends on the type.
+type EligibilityRule struct {
+	ID          uint     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CourseID    uint     `gorm:"not null;index" json:"course_id" binding:"required"`
+	Type        string   `gorm:"type:text;not null" json:"type" binding:"required"`       // MIN_AGE, MAX_AGE, GROUP, COMPLETED
+	Age         int      `gorm:"not null;default:0" json:"age,omitempty"`                 // MIN_AGE, MAX_AGE
+	Groups      []string `gorm:"type:text;serializer:json" json:"groups,omitempty"`       // GROUP: member of any of them
+	CourseCodes []string `gorm:"type:text;serializer:json" json:"course_codes,omitempty"` // COMPLETED: completed all of them
+}
+
+// StudentGroupMember puts a student in a named group used by GROUP eligibility rules
+type StudentGroupMember struct {
+	GroupName string `gorm:"primaryKey" json:"group_name"`
+	StudentID uint   `gorm:"primaryKey" json:"student_id"`
+}
+
+// CourseCompletion records that a student completed a course in a previous term, by course code
+type CourseCompletion struct {
+	StudentID  uint   `gorm:"primaryKey" json:"student_id" binding:"required"`
+	CourseCode string `gorm:"primaryKey" json:"course_code" binding:"required"`
+}
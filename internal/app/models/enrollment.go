@@ -4,6 +4,7 @@ import "time"
 
 type Enrollment struct {
 	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	TermID     uint      `gorm:"not null;default:0;index"`
 	StudentID  uint      `gorm:"not null;uniqueIndex:idx_student_course"`
 	CourseID   uint      `gorm:"not null;uniqueIndex:idx_student_course"`
 	Position   int       `gorm:"not null;uniqueIndex:idx_student_course"`
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// LotteryApplication is a student's request for a seat, drawn when the application window closes
+type LotteryApplication struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"-"`
+	TermID    uint      `gorm:"not null;default:0;uniqueIndex:idx_application_student_course" json:"-"`
+	StudentID uint      `gorm:"not null;uniqueIndex:idx_application_student_course" json:"student_id"`
+	CourseID  uint      `gorm:"not null;uniqueIndex:idx_application_student_course" json:"course_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// LotteryRun records the inputs and totals of a lottery draw; the draw is reproducible from Seed
+type LotteryRun struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	TermID         uint      `gorm:"not null;default:0;index" json:"term_id"`
+	Seed           int64     `gorm:"not null" json:"seed"`
+	WaitlistLosers bool      `gorm:"not null" json:"waitlist_losers"`
+	Applications   int       `gorm:"not null" json:"applications"`
+	Enrolled       int       `gorm:"not null" json:"enrolled"`
+	Waitlisted     int       `gorm:"not null" json:"waitlisted"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// LotteryResult is the outcome of one application in a draw
+type LotteryResult struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement" json:"-"`
+	RunID     uint   `gorm:"not null;index" json:"run_id"`
+	StudentID uint   `gorm:"not null" json:"student_id"`
+	CourseID  uint   `gorm:"not null" json:"course_id"`
+	DrawOrder int    `gorm:"not null" json:"draw_order"` // 0-based order within the course
+	Outcome   string `gorm:"type:text;not null" json:"outcome"`
+}
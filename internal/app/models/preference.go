@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// CoursePreference is a student's ranked course list for preference-based assignment
+type CoursePreference struct {
+	TermID     uint      `gorm:"primaryKey;default:0" json:"-"`
+	StudentID  uint      `gorm:"primaryKey" json:"-"`
+	CourseIDs  []uint    `gorm:"type:text;serializer:json;not null" json:"course_ids"` // most wanted first
+	MaxCourses int       `gorm:"not null" json:"max_courses"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
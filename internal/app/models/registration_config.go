@@ -2,7 +2,15 @@ package models
 
 type RegistrationConfig struct {
 	ID        uint   `gorm:"primaryKey"`
-	Enabled   bool   `gorm:"not null;default:false"`
+	TermID    uint   `gorm:"not null;default:0;uniqueIndex"`
+	Phase     string `gorm:"type:text;not null;default:SETUP"`
+	Mode      string `gorm:"type:text;not null;default:FCFS"`
 	StartTime string `gorm:"type:text"`
 	EndTime   string `gorm:"type:text"`
+
+	// Per-student limits; 0 means no limit
+	MaxCourses int `gorm:"not null;default:0"`
+	MaxCredits int `gorm:"not null;default:0"`
+
+	MaxSpecialCourses int `gorm:"not null;default:0"` // 0 means no limit
 }
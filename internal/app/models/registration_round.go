@@ -0,0 +1,16 @@
+package models
+
+type RegistrationRound struct {
+	ID         uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	TermID     uint   `gorm:"not null;default:0;index" json:"term_id"`
+	Name       string `gorm:"not null" json:"name" binding:"required"`
+	StartTime  string `gorm:"type:text;not null" json:"start_time" binding:"required"`  // "2025-01-20-09-00"
+	EndTime    string `gorm:"type:text;not null" json:"end_time" binding:"required"`    // "2025-01-25-18-00"
+	AllowedOps string `gorm:"type:text;not null" json:"allowed_ops" binding:"required"` // ENROLL_ONLY, ENROLL_CANCEL, CANCEL_ONLY
+	OpenToAll  bool   `gorm:"not null;default:false" json:"open_to_all"`                // false: only RoundEligibleStudent rows
+}
+
+type RoundEligibleStudent struct {
+	RoundID   uint `gorm:"primaryKey"`
+	StudentID uint `gorm:"primaryKey"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// SpecialCourseRule lists who may enroll in a special course: students listed by ID or belonging to a listed cohort.
+// A special course without a rule is open to every student.
+type SpecialCourseRule struct {
+	CourseID   uint     `gorm:"primaryKey" json:"course_id"`
+	StudentIDs []uint   `gorm:"type:text;serializer:json;not null" json:"student_ids"`
+	Cohorts    []string `gorm:"type:text;serializer:json;not null" json:"cohorts"`
+}
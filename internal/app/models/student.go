@@ -5,4 +5,5 @@ type Student struct {
 	Name        string `gorm:"not null" json:"name" binding:"required"`
 	BirthDate   string `gorm:"not null" json:"birth_date" binding:"required"`
 	PhoneNumber string `gorm:"unique; not null" json:"phone_number" binding:"required"`
+	Cohort      string `gorm:"not null; default:''" json:"cohort"`
 }
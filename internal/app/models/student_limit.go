@@ -0,0 +1,9 @@
+package models
+
+// StudentLimit overrides the term's course and credit limits for one student; 0 means no limit
+type StudentLimit struct {
+	TermID     uint `gorm:"primaryKey;default:0" json:"-"`
+	StudentID  uint `gorm:"primaryKey" json:"student_id"`
+	MaxCourses int  `gorm:"not null" json:"max_courses"`
+	MaxCredits int  `gorm:"not null" json:"max_credits"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Term scopes courses, enrollments, the registration config and schedule, lottery applications, carts,
+// preferences and limit overrides; exactly one term is active at a time. Students are shared by all terms.
+type Term struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string    `gorm:"unique;not null" json:"name" binding:"required"`
+	Active    bool      `gorm:"not null;default:false" json:"active"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TermStudent puts a student on a term's roster; only students on the active term's roster can register
+type TermStudent struct {
+	TermID    uint `gorm:"primaryKey"`
+	StudentID uint `gorm:"primaryKey;index"`
+}
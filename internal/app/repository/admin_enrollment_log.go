@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type AdminEnrollmentLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminEnrollmentLogRepository(db *gorm.DB) *AdminEnrollmentLogRepository {
+	return &AdminEnrollmentLogRepository{db: db}
+}
+
+func (r *AdminEnrollmentLogRepository) InsertLog(log *models.AdminEnrollmentLog) error {
+	if err := r.db.Create(log).Error; err != nil {
+		return fmt.Errorf("create failed: %w", err)
+	}
+	return nil
+}
+
+// FetchAllLogs returns all logs, newest first
+func (r *AdminEnrollmentLogRepository) FetchAllLogs() ([]models.AdminEnrollmentLog, error) {
+	var logs []models.AdminEnrollmentLog
+	if err := r.db.Order("id DESC").Find(&logs).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return logs, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type CartRepository struct {
+	db *gorm.DB
+}
+
+func NewCartRepository(db *gorm.DB) *CartRepository {
+	return &CartRepository{db: db}
+}
+
+// FetchCart returns a student's cart items in the order they were added
+func (r *CartRepository) FetchCart(termID uint, studentID uint) ([]models.CartItem, error) {
+	var items []models.CartItem
+	if err := r.db.Where("term_id = ? AND student_id = ?", termID, studentID).Order("created_at, course_id").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return items, nil
+}
+
+func (r *CartRepository) InsertCartItem(item *models.CartItem) error {
+	if err := r.db.Create(item).Error; err != nil {
+		return fmt.Errorf("create failed: %w", err)
+	}
+	return nil
+}
+
+func (r *CartRepository) DeleteCartItem(termID uint, studentID uint, courseID uint) error {
+	result := r.db.Where("term_id = ? AND student_id = ? AND course_id = ?", termID, studentID, courseID).Delete(&models.CartItem{})
+	if result.Error != nil {
+		return fmt.Errorf("delete failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return e.ErrNotInCart
+	}
+	return nil
+}
+
+// DeleteCartItems removes the given courses from a student's cart, ignoring ones that are not in it
+func (r *CartRepository) DeleteCartItems(termID uint, studentID uint, courseIDs []uint) error {
+	if len(courseIDs) == 0 {
+		return nil
+	}
+	if err := r.db.Where("term_id = ? AND student_id = ? AND course_id IN ?", termID, studentID, courseIDs).Delete(&models.CartItem{}).Error; err != nil {
+		return fmt.Errorf("delete failed: %w", err)
+	}
+	return nil
+}
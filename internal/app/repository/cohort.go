@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type CohortRepository struct {
+	db *gorm.DB
+}
+
+func NewCohortRepository(db *gorm.DB) *CohortRepository {
+	return &CohortRepository{db: db}
+}
+
+func (r *CohortRepository) FetchAllCohorts() ([]models.Cohort, error) {
+	var cohorts []models.Cohort
+	if err := r.db.Order("entry_offset_minutes").Find(&cohorts).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return cohorts, nil
+}
+
+// ReplaceCohorts replaces all cohort definitions
+func (r *CohortRepository) ReplaceCohorts(cohorts []models.Cohort) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.Cohort{}).Error; err != nil {
+			return fmt.Errorf("delete failed: %w", err)
+		}
+		if len(cohorts) == 0 {
+			return nil
+		}
+		if err := tx.Create(&cohorts).Error; err != nil {
+			return fmt.Errorf("create failed: %w", err)
+		}
+		return nil
+	})
+}
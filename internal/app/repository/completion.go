@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CompletionRepository struct {
+	db *gorm.DB
+}
+
+func NewCompletionRepository(db *gorm.DB) *CompletionRepository {
+	return &CompletionRepository{db: db}
+}
+
+func (r *CompletionRepository) FetchAllCompletions() ([]models.CourseCompletion, error) {
+	var completions []models.CourseCompletion
+	if err := r.db.Find(&completions).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return completions, nil
+}
+
+// BatchInsertCompletions adds completions; ones already recorded are skipped
+func (r *CompletionRepository) BatchInsertCompletions(completions []models.CourseCompletion) error {
+	if len(completions) == 0 {
+		return nil
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(completions, studentBatchSize).Error; err != nil {
+		return fmt.Errorf("create in batches failed: %w", err)
+	}
+	return nil
+}
@@ -25,12 +25,10 @@ func (r *CourseRepository) BatchInsertCourses(courses []models.Course) error {
 	return nil
 }
 
-func (r *CourseRepository) DeleteAllCourses() error {
-	if err := r.db.Migrator().DropTable(&models.Course{}); err != nil {
-		return fmt.Errorf("drop table failed: %w", err)
-	}
-	if err := r.db.AutoMigrate(&models.Course{}); err != nil {
-		return fmt.Errorf("auto migrate failed: %w", err)
+// DeleteAllCourses deletes the courses of a term; other terms are kept
+func (r *CourseRepository) DeleteAllCourses(termID uint) error {
+	if err := r.db.Where("term_id = ?", termID).Delete(&models.Course{}).Error; err != nil {
+		return fmt.Errorf("delete failed: %w", err)
 	}
 	return nil
 }
@@ -43,17 +41,17 @@ func (r *CourseRepository) InsertCourse(course *models.Course) error {
 	return nil
 }
 
-func (r *CourseRepository) FetchAllCourses() ([]models.Course, error) {
+func (r *CourseRepository) FetchAllCourses(termID uint) ([]models.Course, error) {
 	var courses []models.Course
-	result := r.db.Find(&courses)
+	result := r.db.Where("term_id = ?", termID).Order("id").Find(&courses)
 	if result.Error != nil {
 		return nil, fmt.Errorf("find failed: %w", result.Error)
 	}
 	return courses, nil
 }
 
-func (r *CourseRepository) DeleteCourse(courseID uint) error {
-	result := r.db.Delete(&models.Course{}, courseID)
+func (r *CourseRepository) DeleteCourse(termID uint, courseID uint) error {
+	result := r.db.Where("term_id = ?", termID).Delete(&models.Course{}, courseID)
 	if result.Error != nil {
 		return fmt.Errorf("delete failed: %w", result.Error)
 	}
@@ -62,3 +60,34 @@ func (r *CourseRepository) DeleteCourse(courseID uint) error {
 	}
 	return nil
 }
+
+func (r *CourseRepository) CourseExists(termID uint, courseID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Course{}).Where("term_id = ? AND id = ?", termID, courseID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("count failed: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *CourseRepository) FetchCoursesByIDs(termID uint, courseIDs []uint) ([]models.Course, error) {
+	var courses []models.Course
+	if len(courseIDs) == 0 {
+		return courses, nil
+	}
+	if err := r.db.Where("term_id = ? AND id IN ?", termID, courseIDs).Find(&courses).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return courses, nil
+}
+
+// FetchCoursesInAnyTerm is FetchCoursesByIDs for courses that may belong to past terms
+func (r *CourseRepository) FetchCoursesInAnyTerm(courseIDs []uint) ([]models.Course, error) {
+	var courses []models.Course
+	if len(courseIDs) == 0 {
+		return courses, nil
+	}
+	if err := r.db.Where("id IN ?", courseIDs).Find(&courses).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return courses, nil
+}
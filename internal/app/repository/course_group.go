@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type CourseGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewCourseGroupRepository(db *gorm.DB) *CourseGroupRepository {
+	return &CourseGroupRepository{db: db}
+}
+
+// FetchAllGroups returns the groups of termID's courses
+func (r *CourseGroupRepository) FetchAllGroups(termID uint) ([]models.CourseGroup, error) {
+	var groups []models.CourseGroup
+	if err := r.db.Order("id").Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	courseIDs, err := termCourseIDs(r.db, termID)
+	if err != nil {
+		return nil, err
+	}
+
+	termGroups := make([]models.CourseGroup, 0, len(groups))
+	for _, group := range groups {
+		if inTerm(group, courseIDs) {
+			termGroups = append(termGroups, group)
+		}
+	}
+	return termGroups, nil
+}
+
+func (r *CourseGroupRepository) InsertGroup(group *models.CourseGroup) error {
+	if err := r.db.Create(group).Error; err != nil {
+		return fmt.Errorf("create failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteGroup removes a group of termID's courses; groups of other terms are not found
+func (r *CourseGroupRepository) DeleteGroup(termID uint, groupID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var group models.CourseGroup
+		if err := tx.First(&group, groupID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return e.ErrCourseGroupNotFound
+			}
+			return fmt.Errorf("find failed: %w", err)
+		}
+		courseIDs, err := termCourseIDs(tx, termID)
+		if err != nil {
+			return err
+		}
+		if !inTerm(group, courseIDs) {
+			return e.ErrCourseGroupNotFound
+		}
+
+		if err := tx.Delete(&models.CourseGroup{}, groupID).Error; err != nil {
+			return fmt.Errorf("delete failed: %w", err)
+		}
+		return nil
+	})
+}
+
+// termCourseIDs returns the IDs of termID's courses
+func termCourseIDs(db *gorm.DB, termID uint) (map[uint]struct{}, error) {
+	var ids []uint
+	if err := db.Model(&models.Course{}).Where("term_id = ?", termID).Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("find courses failed: %w", err)
+	}
+	courseIDs := make(map[uint]struct{}, len(ids))
+	for _, id := range ids {
+		courseIDs[id] = struct{}{}
+	}
+	return courseIDs, nil
+}
+
+// inTerm reports whether the group ties courses of the term; course IDs are stored as JSON,
+// so the check is done here instead of in the query. A group only ever holds courses of one term.
+func inTerm(group models.CourseGroup, courseIDs map[uint]struct{}) bool {
+	for _, id := range group.CourseIDs {
+		if _, ok := courseIDs[id]; ok {
+			return true
+		}
+	}
+	return false
+}
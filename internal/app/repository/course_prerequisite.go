@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type PrerequisiteRepository struct {
+	db *gorm.DB
+}
+
+func NewPrerequisiteRepository(db *gorm.DB) *PrerequisiteRepository {
+	return &PrerequisiteRepository{db: db}
+}
+
+func (r *PrerequisiteRepository) FetchAllPrerequisites() ([]models.CoursePrerequisite, error) {
+	var prerequisites []models.CoursePrerequisite
+	if err := r.db.Order("course_code, prerequisite_code").Find(&prerequisites).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return prerequisites, nil
+}
+
+// ReplacePrerequisites replaces the prerequisites of a course code; an empty list removes them
+func (r *PrerequisiteRepository) ReplacePrerequisites(courseCode string, prerequisiteCodes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("course_code = ?", courseCode).Delete(&models.CoursePrerequisite{}).Error; err != nil {
+			return fmt.Errorf("delete failed: %w", err)
+		}
+		if len(prerequisiteCodes) == 0 {
+			return nil
+		}
+
+		prerequisites := make([]models.CoursePrerequisite, len(prerequisiteCodes))
+		for i, prerequisiteCode := range prerequisiteCodes {
+			prerequisites[i] = models.CoursePrerequisite{CourseCode: courseCode, PrerequisiteCode: prerequisiteCode}
+		}
+		if err := tx.Create(&prerequisites).Error; err != nil {
+			return fmt.Errorf("create failed: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type EligibilityRepository struct {
+	db *gorm.DB
+}
+
+func NewEligibilityRepository(db *gorm.DB) *EligibilityRepository {
+	return &EligibilityRepository{db: db}
+}
+
+// FetchAllRules returns the rules of termID's courses
+func (r *EligibilityRepository) FetchAllRules(termID uint) ([]models.EligibilityRule, error) {
+	var rules []models.EligibilityRule
+	courses := r.db.Model(&models.Course{}).Select("id").Where("term_id = ?", termID)
+	if err := r.db.Where("course_id IN (?)", courses).Order("course_id, id").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *EligibilityRepository) InsertRule(rule *models.EligibilityRule) error {
+	if err := r.db.Create(rule).Error; err != nil {
+		return fmt.Errorf("create failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteRule removes a rule of termID's courses; rules of other terms are not found
+func (r *EligibilityRepository) DeleteRule(termID uint, ruleID uint) error {
+	courses := r.db.Model(&models.Course{}).Select("id").Where("term_id = ?", termID)
+	result := r.db.Where("id = ? AND course_id IN (?)", ruleID, courses).Delete(&models.EligibilityRule{})
+	if result.Error != nil {
+		return fmt.Errorf("delete failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return e.ErrEligibilityRuleNotFound
+	}
+	return nil
+}
+
+func (r *EligibilityRepository) FetchAllGroupMembers() ([]models.StudentGroupMember, error) {
+	var members []models.StudentGroupMember
+	if err := r.db.Order("group_name, student_id").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return members, nil
+}
+
+// ReplaceGroupMembers replaces the members of a student group; an empty list removes the group
+func (r *EligibilityRepository) ReplaceGroupMembers(groupName string, studentIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_name = ?", groupName).Delete(&models.StudentGroupMember{}).Error; err != nil {
+			return fmt.Errorf("delete failed: %w", err)
+		}
+		if len(studentIDs) == 0 {
+			return nil
+		}
+
+		members := make([]models.StudentGroupMember, len(studentIDs))
+		for i, studentID := range studentIDs {
+			members[i] = models.StudentGroupMember{GroupName: groupName, StudentID: studentID}
+		}
+		if err := tx.CreateInBatches(members, studentBatchSize).Error; err != nil {
+			return fmt.Errorf("create in batches failed: %w", err)
+		}
+		return nil
+	})
+}
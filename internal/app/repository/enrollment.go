@@ -2,11 +2,15 @@ package repository
 
 import (
 	"course-reg/internal/app/models"
+	"errors"
 	"fmt"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const enrollmentBatchSize = 500
+
 type EnrollmentRepository struct {
 	db *gorm.DB
 }
@@ -31,8 +35,20 @@ func (r *EnrollmentRepository) BatchInsertEnrollments(enrollments []models.Enrol
 	return nil
 }
 
+// InsertEnrollmentsIfAbsent adds enrollments in one transaction; rows already stored are skipped,
+// so rows replayed from the worker's journal are not duplicated
+func (r *EnrollmentRepository) InsertEnrollmentsIfAbsent(enrollments []models.Enrollment) error {
+	if len(enrollments) == 0 {
+		return nil
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(enrollments, enrollmentBatchSize).Error; err != nil {
+		return fmt.Errorf("create in batches failed: %w", err)
+	}
+	return nil
+}
+
 func (r *EnrollmentRepository) DeleteEnrollment(studentID uint, courseID uint) error {
-	result := r.db.Where("student_id = ? AND course_id = ?", studentID, courseID).Delete(&models.Enrollment{})
+	result := r.db.Where("student_id = ? AND course_id = ? AND is_waitlist = ?", studentID, courseID, false).Delete(&models.Enrollment{})
 	if result.Error != nil {
 		return fmt.Errorf("delete failed: %w", result.Error)
 	}
@@ -42,18 +58,122 @@ func (r *EnrollmentRepository) DeleteEnrollment(studentID uint, courseID uint) e
 	return nil
 }
 
-func (r *EnrollmentRepository) DeleteAllEnrollments() error {
-	if err := r.db.Migrator().DropTable(&models.Enrollment{}); err != nil {
-		return fmt.Errorf("drop table failed: %w", err)
-	}
-	if err := r.db.AutoMigrate(&models.Enrollment{}); err != nil {
-		return fmt.Errorf("auto migrate failed: %w", err)
+// DeleteEnrollments removes several enrollments of a student in one transaction
+func (r *EnrollmentRepository) DeleteEnrollments(studentID uint, courseIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("student_id = ? AND course_id IN ? AND is_waitlist = ?", studentID, courseIDs, false).Delete(&models.Enrollment{})
+		if result.Error != nil {
+			return fmt.Errorf("delete failed: %w", result.Error)
+		}
+		if result.RowsAffected != int64(len(courseIDs)) {
+			return fmt.Errorf("enrollment not found") // todo: 커스텀 예외
+		}
+		return nil
+	})
+}
+
+// DeleteWaitlistEntry removes a waitlist row and moves everyone behind it up by one position
+func (r *EnrollmentRepository) DeleteWaitlistEntry(studentID uint, courseID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var entry models.Enrollment
+		if err := tx.Where("student_id = ? AND course_id = ? AND is_waitlist = ?", studentID, courseID, true).
+			Take(&entry).Error; err != nil {
+			return fmt.Errorf("find waitlist entry failed: %w", err)
+		}
+
+		if err := tx.Delete(&entry).Error; err != nil {
+			return fmt.Errorf("delete failed: %w", err)
+		}
+
+		if err := tx.Model(&models.Enrollment{}).
+			Where("course_id = ? AND is_waitlist = ? AND position > ?", courseID, true, entry.Position).
+			Update("position", gorm.Expr("position - 1")).Error; err != nil {
+			return fmt.Errorf("shift positions failed: %w", err)
+		}
+		return nil
+	})
+}
+
+// PromoteWaitlistEntry turns a waitlist row into an enrollment and moves everyone behind it up by one position
+func (r *EnrollmentRepository) PromoteWaitlistEntry(studentID uint, courseID uint, position int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var entry models.Enrollment
+		if err := tx.Where("student_id = ? AND course_id = ? AND is_waitlist = ?", studentID, courseID, true).
+			Take(&entry).Error; err != nil {
+			return fmt.Errorf("find waitlist entry failed: %w", err)
+		}
+		waitlistPos := entry.Position
+
+		if err := tx.Model(&entry).Updates(map[string]interface{}{
+			"is_waitlist": false,
+			"position":    position,
+		}).Error; err != nil {
+			return fmt.Errorf("promote failed: %w", err)
+		}
+
+		if err := tx.Model(&models.Enrollment{}).
+			Where("course_id = ? AND is_waitlist = ? AND position > ?", courseID, true, waitlistPos).
+			Update("position", gorm.Expr("position - 1")).Error; err != nil {
+			return fmt.Errorf("shift positions failed: %w", err)
+		}
+		return nil
+	})
+}
+
+// SwapEnrollment drops the enrollment in fromCourseID and enrolls in toCourseID at the given position
+// in one transaction. A waitlist entry for toCourseID is promoted instead of inserting a new row.
+// The new row belongs to the same term as the dropped one.
+func (r *EnrollmentRepository) SwapEnrollment(studentID uint, fromCourseID uint, toCourseID uint, position int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var from models.Enrollment
+		if err := tx.Where("student_id = ? AND course_id = ? AND is_waitlist = ?", studentID, fromCourseID, false).Take(&from).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("enrollment not found") // todo: 커스텀 예외
+			}
+			return fmt.Errorf("find failed: %w", err)
+		}
+		if err := tx.Delete(&from).Error; err != nil {
+			return fmt.Errorf("delete failed: %w", err)
+		}
+
+		var entry models.Enrollment
+		err := tx.Where("student_id = ? AND course_id = ? AND is_waitlist = ?", studentID, toCourseID, true).Take(&entry).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := tx.Create(&models.Enrollment{TermID: from.TermID, StudentID: studentID, CourseID: toCourseID, Position: position}).Error; err != nil {
+				return fmt.Errorf("create failed: %w", err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("find waitlist entry failed: %w", err)
+		}
+
+		waitlistPos := entry.Position
+		if err := tx.Model(&entry).Updates(map[string]interface{}{
+			"is_waitlist": false,
+			"position":    position,
+		}).Error; err != nil {
+			return fmt.Errorf("promote failed: %w", err)
+		}
+		if err := tx.Model(&models.Enrollment{}).
+			Where("course_id = ? AND is_waitlist = ? AND position > ?", toCourseID, true, waitlistPos).
+			Update("position", gorm.Expr("position - 1")).Error; err != nil {
+			return fmt.Errorf("shift positions failed: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteAllEnrollments deletes the enrollments of a term; other terms are kept
+func (r *EnrollmentRepository) DeleteAllEnrollments(termID uint) error {
+	if err := r.db.Where("term_id = ?", termID).Delete(&models.Enrollment{}).Error; err != nil {
+		return fmt.Errorf("delete failed: %w", err)
 	}
 	return nil
 }
 
-func (r *EnrollmentRepository) FetchAllEnrollments() ([]models.Enrollment, error) {
+func (r *EnrollmentRepository) FetchAllEnrollments(termID uint) ([]models.Enrollment, error) {
 	var enrollments []models.Enrollment
-	err := r.db.Find(&enrollments).Error
+	err := r.db.Where("term_id = ?", termID).Find(&enrollments).Error
 	return enrollments, err
 }
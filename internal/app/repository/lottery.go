@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+const lotteryResultBatchSize = 500
+
+type LotteryRepository struct {
+	db *gorm.DB
+}
+
+func NewLotteryRepository(db *gorm.DB) *LotteryRepository {
+	return &LotteryRepository{db: db}
+}
+
+func (r *LotteryRepository) InsertApplication(application *models.LotteryApplication) error {
+	if err := r.db.Create(application).Error; err != nil {
+		return fmt.Errorf("create failed: %w", err)
+	}
+	return nil
+}
+
+func (r *LotteryRepository) DeleteApplication(termID uint, studentID uint, courseID uint) error {
+	result := r.db.Where("term_id = ? AND student_id = ? AND course_id = ?", termID, studentID, courseID).Delete(&models.LotteryApplication{})
+	if result.Error != nil {
+		return fmt.Errorf("delete failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return e.ErrNotApplied
+	}
+	return nil
+}
+
+func (r *LotteryRepository) FetchApplicationsByStudent(termID uint, studentID uint) ([]models.LotteryApplication, error) {
+	var applications []models.LotteryApplication
+	if err := r.db.Where("term_id = ? AND student_id = ?", termID, studentID).Order("id").Find(&applications).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return applications, nil
+}
+
+func (r *LotteryRepository) FetchAllApplications(termID uint) ([]models.LotteryApplication, error) {
+	var applications []models.LotteryApplication
+	if err := r.db.Where("term_id = ?", termID).Order("id").Find(&applications).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return applications, nil
+}
+
+// SaveRun stores a draw with the enrollments it assigned and its results, and consumes the applications
+// of the run's term, in one transaction so seats are never assigned without a run record
+func (r *LotteryRepository) SaveRun(run *models.LotteryRun, results []models.LotteryResult, enrollments []models.Enrollment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(enrollments) > 0 {
+			if err := tx.CreateInBatches(enrollments, enrollmentBatchSize).Error; err != nil {
+				return fmt.Errorf("create enrollments failed: %w", err)
+			}
+		}
+		if err := tx.Create(run).Error; err != nil {
+			return fmt.Errorf("create run failed: %w", err)
+		}
+		for i := range results {
+			results[i].RunID = run.ID
+		}
+		if len(results) > 0 {
+			if err := tx.CreateInBatches(results, lotteryResultBatchSize).Error; err != nil {
+				return fmt.Errorf("create results failed: %w", err)
+			}
+		}
+		if err := tx.Where("term_id = ?", run.TermID).Delete(&models.LotteryApplication{}).Error; err != nil {
+			return fmt.Errorf("delete applications failed: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *LotteryRepository) FetchAllRuns(termID uint) ([]models.LotteryRun, error) {
+	var runs []models.LotteryRun
+	if err := r.db.Where("term_id = ?", termID).Order("id").Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return runs, nil
+}
+
+func (r *LotteryRepository) FetchRunResults(runID uint) ([]models.LotteryResult, error) {
+	var results []models.LotteryResult
+	if err := r.db.Where("run_id = ?", runID).Order("course_id, draw_order").Find(&results).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type PreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewPreferenceRepository(db *gorm.DB) *PreferenceRepository {
+	return &PreferenceRepository{db: db}
+}
+
+// SavePreference inserts or replaces the student's preference
+func (r *PreferenceRepository) SavePreference(preference *models.CoursePreference) error {
+	if err := r.db.Save(preference).Error; err != nil {
+		return fmt.Errorf("save failed: %w", err)
+	}
+	return nil
+}
+
+func (r *PreferenceRepository) FetchPreference(termID uint, studentID uint) (*models.CoursePreference, error) {
+	var preference models.CoursePreference
+	if err := r.db.First(&preference, "term_id = ? AND student_id = ?", termID, studentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrNoPreference
+		}
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return &preference, nil
+}
+
+func (r *PreferenceRepository) FetchAllPreferences(termID uint) ([]models.CoursePreference, error) {
+	var preferences []models.CoursePreference
+	if err := r.db.Where("term_id = ?", termID).Order("student_id").Find(&preferences).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return preferences, nil
+}
+
+func (r *PreferenceRepository) DeletePreference(termID uint, studentID uint) error {
+	result := r.db.Where("term_id = ? AND student_id = ?", termID, studentID).Delete(&models.CoursePreference{})
+	if result.Error != nil {
+		return fmt.Errorf("delete failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return e.ErrNoPreference
+	}
+	return nil
+}
+
+// CommitAssignment writes the assigned enrollments and consumes all of the term's preferences in one transaction
+func (r *PreferenceRepository) CommitAssignment(termID uint, enrollments []models.Enrollment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(enrollments) > 0 {
+			if err := tx.CreateInBatches(enrollments, enrollmentBatchSize).Error; err != nil {
+				return fmt.Errorf("create enrollments failed: %w", err)
+			}
+		}
+		if err := tx.Where("term_id = ?", termID).Delete(&models.CoursePreference{}).Error; err != nil {
+			return fmt.Errorf("delete preferences failed: %w", err)
+		}
+		return nil
+	})
+}
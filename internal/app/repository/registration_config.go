@@ -6,8 +6,7 @@ import (
 	"gorm.io/gorm"
 )
 
-const defaultConfigID = 1
-
+// RegistrationConfigRepository stores one registration config per term
 type RegistrationConfigRepository struct {
 	db *gorm.DB
 }
@@ -16,9 +15,9 @@ func NewRegistrationConfigRepository(db *gorm.DB) *RegistrationConfigRepository
 	return &RegistrationConfigRepository{db: db}
 }
 
-func (r *RegistrationConfigRepository) GetConfig() (*models.RegistrationConfig, error) {
+func (r *RegistrationConfigRepository) GetConfig(termID uint) (*models.RegistrationConfig, error) {
 	var config models.RegistrationConfig
-	if err := r.db.First(&config, defaultConfigID).Error; err != nil {
+	if err := r.db.Where("term_id = ?", termID).Take(&config).Error; err != nil {
 		return nil, err
 	}
 	return &config, nil
@@ -28,17 +27,38 @@ func (r *RegistrationConfigRepository) CreateConfig(config *models.RegistrationC
 	return r.db.Create(config).Error
 }
 
-func (r *RegistrationConfigRepository) UpdateEnabled(enabled bool) error {
+func (r *RegistrationConfigRepository) UpdatePhase(termID uint, phase string) error {
+	return r.db.Model(&models.RegistrationConfig{}).
+		Where("term_id = ?", termID).
+		Update("phase", phase).Error
+}
+
+func (r *RegistrationConfigRepository) UpdateMode(termID uint, mode string) error {
 	return r.db.Model(&models.RegistrationConfig{}).
-		Where("id = ?", defaultConfigID).
-		Update("enabled", enabled).Error
+		Where("term_id = ?", termID).
+		Update("mode", mode).Error
 }
 
-func (r *RegistrationConfigRepository) UpdatePeriod(startTime, endTime string) error {
+func (r *RegistrationConfigRepository) UpdatePeriod(termID uint, startTime, endTime string) error {
 	return r.db.Model(&models.RegistrationConfig{}).
-		Where("id = ?", defaultConfigID).
+		Where("term_id = ?", termID).
 		Updates(map[string]interface{}{
 			"start_time": startTime,
 			"end_time":   endTime,
 		}).Error
 }
+
+func (r *RegistrationConfigRepository) UpdateLimits(termID uint, maxCourses, maxCredits int) error {
+	return r.db.Model(&models.RegistrationConfig{}).
+		Where("term_id = ?", termID).
+		Updates(map[string]interface{}{
+			"max_courses": maxCourses,
+			"max_credits": maxCredits,
+		}).Error
+}
+
+func (r *RegistrationConfigRepository) UpdateSpecialLimit(termID uint, maxSpecialCourses int) error {
+	return r.db.Model(&models.RegistrationConfig{}).
+		Where("term_id = ?", termID).
+		Update("max_special_courses", maxSpecialCourses).Error
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type RegistrationRoundRepository struct {
+	db *gorm.DB
+}
+
+func NewRegistrationRoundRepository(db *gorm.DB) *RegistrationRoundRepository {
+	return &RegistrationRoundRepository{db: db}
+}
+
+func (r *RegistrationRoundRepository) FetchAllRounds(termID uint) ([]models.RegistrationRound, error) {
+	var rounds []models.RegistrationRound
+	if err := r.db.Where("term_id = ?", termID).Order("start_time").Find(&rounds).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return rounds, nil
+}
+
+// FetchAllEligibleStudents returns the eligible students of every round of termID
+func (r *RegistrationRoundRepository) FetchAllEligibleStudents(termID uint) ([]models.RoundEligibleStudent, error) {
+	var eligible []models.RoundEligibleStudent
+	rounds := r.db.Model(&models.RegistrationRound{}).Select("id").Where("term_id = ?", termID)
+	if err := r.db.Where("round_id IN (?)", rounds).Find(&eligible).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return eligible, nil
+}
+
+func (r *RegistrationRoundRepository) InsertRound(round *models.RegistrationRound) error {
+	if err := r.db.Create(round).Error; err != nil {
+		return fmt.Errorf("create failed: %w", err)
+	}
+	return nil
+}
+
+// UpdateRound replaces a round of round.TermID; rounds of other terms are not found
+func (r *RegistrationRoundRepository) UpdateRound(round *models.RegistrationRound) error {
+	result := r.db.Model(round).Where("term_id = ?", round.TermID).Select("*").Updates(round)
+	if result.Error != nil {
+		return fmt.Errorf("update failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return e.ErrRoundNotFound
+	}
+	return nil
+}
+
+func (r *RegistrationRoundRepository) DeleteRound(termID uint, roundID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("term_id = ?", termID).Delete(&models.RegistrationRound{}, roundID)
+		if result.Error != nil {
+			return fmt.Errorf("delete failed: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return e.ErrRoundNotFound
+		}
+		if err := tx.Where("round_id = ?", roundID).Delete(&models.RoundEligibleStudent{}).Error; err != nil {
+			return fmt.Errorf("delete eligible students failed: %w", err)
+		}
+		return nil
+	})
+}
+
+// ReplaceEligibleStudents replaces the eligible student set of a round of termID
+func (r *RegistrationRoundRepository) ReplaceEligibleStudents(termID uint, roundID uint, studentIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.RegistrationRound{}).Where("term_id = ? AND id = ?", termID, roundID).Count(&count).Error; err != nil {
+			return fmt.Errorf("count failed: %w", err)
+		}
+		if count == 0 {
+			return e.ErrRoundNotFound
+		}
+		if err := tx.Where("round_id = ?", roundID).Delete(&models.RoundEligibleStudent{}).Error; err != nil {
+			return fmt.Errorf("delete failed: %w", err)
+		}
+		if len(studentIDs) == 0 {
+			return nil
+		}
+
+		eligible := make([]models.RoundEligibleStudent, len(studentIDs))
+		for i, studentID := range studentIDs {
+			eligible[i] = models.RoundEligibleStudent{RoundID: roundID, StudentID: studentID}
+		}
+		if err := tx.CreateInBatches(eligible, studentBatchSize).Error; err != nil {
+			return fmt.Errorf("create in batches failed: %w", err)
+		}
+		return nil
+	})
+}
@@ -4,30 +4,133 @@ import "course-reg/internal/app/models"
 
 type StudentRepositoryInterface interface {
 	FetchPassword(username string) (uint, string, error)
-	BatchInsertStudents(students []models.Student) error
-	DeleteAllStudents() error
+	BatchInsertStudents(termID uint, students []models.Student) error
+	DeleteTermStudents(termID uint) error
 	FetchAllStudents() ([]models.Student, error)
+	FetchTermStudents(termID uint) ([]models.Student, error)
+	UpdateCohorts(assignments map[uint]string) error
 }
 
 type CourseRepositoryInterface interface {
 	BatchInsertCourses(courses []models.Course) error
-	DeleteAllCourses() error
+	DeleteAllCourses(termID uint) error
 	InsertCourse(course *models.Course) error
-	DeleteCourse(courseID uint) error
-	FetchAllCourses() ([]models.Course, error)
+	DeleteCourse(termID uint, courseID uint) error
+	FetchAllCourses(termID uint) ([]models.Course, error)
+	CourseExists(termID uint, courseID uint) (bool, error)
+	FetchCoursesByIDs(termID uint, courseIDs []uint) ([]models.Course, error)
+	FetchCoursesInAnyTerm(courseIDs []uint) ([]models.Course, error)
 }
 
 type EnrollmentRepositoryInterface interface {
 	InsertEnrollment(enrollment *models.Enrollment) error
 	BatchInsertEnrollments(enrollments []models.Enrollment) error
+	InsertEnrollmentsIfAbsent(enrollments []models.Enrollment) error
 	DeleteEnrollment(studentID uint, courseID uint) error
-	FetchAllEnrollments() ([]models.Enrollment, error)
-	DeleteAllEnrollments() error
+	DeleteEnrollments(studentID uint, courseIDs []uint) error
+	DeleteWaitlistEntry(studentID uint, courseID uint) error
+	PromoteWaitlistEntry(studentID uint, courseID uint, position int) error
+	SwapEnrollment(studentID uint, fromCourseID uint, toCourseID uint, position int) error
+	FetchAllEnrollments(termID uint) ([]models.Enrollment, error)
+	DeleteAllEnrollments(termID uint) error
 }
 
 type RegistrationConfigRepositoryInterface interface {
-	GetConfig() (*models.RegistrationConfig, error)
+	GetConfig(termID uint) (*models.RegistrationConfig, error)
 	CreateConfig(config *models.RegistrationConfig) error
-	UpdateEnabled(enabled bool) error
-	UpdatePeriod(startTime, endTime string) error
+	UpdatePhase(termID uint, phase string) error
+	UpdateMode(termID uint, mode string) error
+	UpdatePeriod(termID uint, startTime, endTime string) error
+	UpdateLimits(termID uint, maxCourses, maxCredits int) error
+	UpdateSpecialLimit(termID uint, maxSpecialCourses int) error
+}
+
+type TermRepositoryInterface interface {
+	FetchAllTerms() ([]models.Term, error)
+	FetchActiveTerm() (*models.Term, error)
+	FetchTerm(termID uint) (*models.Term, error)
+	InsertTerm(term *models.Term, config *models.RegistrationConfig, courses []models.Course, studentIDs []uint, rules func(created []models.Course) models.CourseRules) error
+	FetchTermStudentIDs(termID uint) ([]uint, error)
+	SetActiveTerm(termID uint) error
+	InsertTermAdoptingRows(term *models.Term) error
+}
+
+type CourseGroupRepositoryInterface interface {
+	FetchAllGroups(termID uint) ([]models.CourseGroup, error)
+	InsertGroup(group *models.CourseGroup) error
+	DeleteGroup(termID uint, groupID uint) error
+}
+
+type EligibilityRepositoryInterface interface {
+	FetchAllRules(termID uint) ([]models.EligibilityRule, error)
+	InsertRule(rule *models.EligibilityRule) error
+	DeleteRule(termID uint, ruleID uint) error
+	FetchAllGroupMembers() ([]models.StudentGroupMember, error)
+	ReplaceGroupMembers(groupName string, studentIDs []uint) error
+}
+
+type CompletionRepositoryInterface interface {
+	FetchAllCompletions() ([]models.CourseCompletion, error)
+	BatchInsertCompletions(completions []models.CourseCompletion) error
+}
+
+type PrerequisiteRepositoryInterface interface {
+	FetchAllPrerequisites() ([]models.CoursePrerequisite, error)
+	ReplacePrerequisites(courseCode string, prerequisiteCodes []string) error
+}
+
+type SpecialCourseRuleRepositoryInterface interface {
+	FetchAllRules(termID uint) ([]models.SpecialCourseRule, error)
+	SaveRule(rule *models.SpecialCourseRule) error
+	DeleteRule(courseID uint) error
+}
+
+type StudentLimitRepositoryInterface interface {
+	FetchAllStudentLimits(termID uint) ([]models.StudentLimit, error)
+	SaveStudentLimit(limit *models.StudentLimit) error
+	DeleteStudentLimit(termID uint, studentID uint) error
+}
+
+type RegistrationRoundRepositoryInterface interface {
+	FetchAllRounds(termID uint) ([]models.RegistrationRound, error)
+	FetchAllEligibleStudents(termID uint) ([]models.RoundEligibleStudent, error)
+	InsertRound(round *models.RegistrationRound) error
+	UpdateRound(round *models.RegistrationRound) error
+	DeleteRound(termID uint, roundID uint) error
+	ReplaceEligibleStudents(termID uint, roundID uint, studentIDs []uint) error
+}
+
+type CohortRepositoryInterface interface {
+	FetchAllCohorts() ([]models.Cohort, error)
+	ReplaceCohorts(cohorts []models.Cohort) error
+}
+
+type LotteryRepositoryInterface interface {
+	InsertApplication(application *models.LotteryApplication) error
+	DeleteApplication(termID uint, studentID uint, courseID uint) error
+	FetchApplicationsByStudent(termID uint, studentID uint) ([]models.LotteryApplication, error)
+	FetchAllApplications(termID uint) ([]models.LotteryApplication, error)
+	SaveRun(run *models.LotteryRun, results []models.LotteryResult, enrollments []models.Enrollment) error
+	FetchAllRuns(termID uint) ([]models.LotteryRun, error)
+	FetchRunResults(runID uint) ([]models.LotteryResult, error)
+}
+
+type CartRepositoryInterface interface {
+	FetchCart(termID uint, studentID uint) ([]models.CartItem, error)
+	InsertCartItem(item *models.CartItem) error
+	DeleteCartItem(termID uint, studentID uint, courseID uint) error
+	DeleteCartItems(termID uint, studentID uint, courseIDs []uint) error
+}
+
+type PreferenceRepositoryInterface interface {
+	SavePreference(preference *models.CoursePreference) error
+	FetchPreference(termID uint, studentID uint) (*models.CoursePreference, error)
+	FetchAllPreferences(termID uint) ([]models.CoursePreference, error)
+	DeletePreference(termID uint, studentID uint) error
+	CommitAssignment(termID uint, enrollments []models.Enrollment) error
+}
+
+type AdminEnrollmentLogRepositoryInterface interface {
+	InsertLog(log *models.AdminEnrollmentLog) error
+	FetchAllLogs() ([]models.AdminEnrollmentLog, error)
 }
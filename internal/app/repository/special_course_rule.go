@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type SpecialCourseRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewSpecialCourseRuleRepository(db *gorm.DB) *SpecialCourseRuleRepository {
+	return &SpecialCourseRuleRepository{db: db}
+}
+
+// FetchAllRules returns the rules of termID's courses
+func (r *SpecialCourseRuleRepository) FetchAllRules(termID uint) ([]models.SpecialCourseRule, error) {
+	var rules []models.SpecialCourseRule
+	courses := r.db.Model(&models.Course{}).Select("id").Where("term_id = ?", termID)
+	if err := r.db.Where("course_id IN (?)", courses).Order("course_id").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return rules, nil
+}
+
+// SaveRule inserts or replaces the rule of a course
+func (r *SpecialCourseRuleRepository) SaveRule(rule *models.SpecialCourseRule) error {
+	if err := r.db.Save(rule).Error; err != nil {
+		return fmt.Errorf("save failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteRule removes the rule of a course; deleting a missing rule is not an error
+func (r *SpecialCourseRuleRepository) DeleteRule(courseID uint) error {
+	if err := r.db.Where("course_id = ?", courseID).Delete(&models.SpecialCourseRule{}).Error; err != nil {
+		return fmt.Errorf("delete failed: %w", err)
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package repository
 
 import (
+	"course-reg/internal/app/domain/e"
 	"course-reg/internal/app/models"
 	"fmt"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const studentBatchSize = 100
@@ -27,20 +29,35 @@ func (r *StudentRepository) FetchPassword(username string) (uint, string, error)
 	return student.ID, student.BirthDate, nil
 }
 
-func (r *StudentRepository) BatchInsertStudents(students []models.Student) error {
-	// CreateInBatches runs within an internal transaction and automatically rolls back on failure.
-	if err := r.db.CreateInBatches(students, studentBatchSize).Error; err != nil {
-		return fmt.Errorf("create in batches failed: %w", err)
+// BatchInsertStudents puts the students on the roster of termID. A student already registered in another
+// term, by phone number, keeps their ID and gets the uploaded details.
+func (r *StudentRepository) BatchInsertStudents(termID uint, students []models.Student) error {
+	if len(students) == 0 {
+		return nil
 	}
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		upsert := clause.OnConflict{
+			Columns:   []clause.Column{{Name: "phone_number"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "birth_date", "cohort"}),
+		}
+		if err := tx.Clauses(upsert).CreateInBatches(students, studentBatchSize).Error; err != nil {
+			return fmt.Errorf("create in batches failed: %w", err)
+		}
+		roster := make([]models.TermStudent, len(students))
+		for i, student := range students {
+			roster[i] = models.TermStudent{TermID: termID, StudentID: student.ID}
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(roster, studentBatchSize).Error; err != nil {
+			return fmt.Errorf("create roster failed: %w", err)
+		}
+		return nil
+	})
 }
 
-func (r *StudentRepository) DeleteAllStudents() error {
-	if err := r.db.Migrator().DropTable(&models.Student{}); err != nil {
-		return fmt.Errorf("drop table failed: %w", err)
-	}
-	if err := r.db.AutoMigrate(&models.Student{}); err != nil {
-		return fmt.Errorf("auto migrate failed: %w", err)
+// DeleteTermStudents takes every student off the roster of termID; the students and other terms are kept
+func (r *StudentRepository) DeleteTermStudents(termID uint) error {
+	if err := r.db.Where("term_id = ?", termID).Delete(&models.TermStudent{}).Error; err != nil {
+		return fmt.Errorf("delete roster failed: %w", err)
 	}
 	return nil
 }
@@ -53,3 +70,32 @@ func (r *StudentRepository) FetchAllStudents() ([]models.Student, error) {
 	}
 	return students, nil
 }
+
+// FetchTermStudents returns the students on the roster of termID
+func (r *StudentRepository) FetchTermStudents(termID uint) ([]models.Student, error) {
+	var students []models.Student
+	result := r.db.Joins("JOIN term_students ON term_students.student_id = students.id").
+		Where("term_students.term_id = ?", termID).
+		Order("students.id").
+		Find(&students)
+	if result.Error != nil {
+		return nil, fmt.Errorf("find failed: %w", result.Error)
+	}
+	return students, nil
+}
+
+// UpdateCohorts sets the cohort of each given student (studentID -> cohort name)
+func (r *StudentRepository) UpdateCohorts(assignments map[uint]string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for studentID, cohort := range assignments {
+			result := tx.Model(&models.Student{}).Where("id = ?", studentID).Update("cohort", cohort)
+			if result.Error != nil {
+				return fmt.Errorf("update failed: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("student %d: %w", studentID, e.ErrStudentNotFound)
+			}
+		}
+		return nil
+	})
+}
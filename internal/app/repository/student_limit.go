@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"course-reg/internal/app/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type StudentLimitRepository struct {
+	db *gorm.DB
+}
+
+func NewStudentLimitRepository(db *gorm.DB) *StudentLimitRepository {
+	return &StudentLimitRepository{db: db}
+}
+
+func (r *StudentLimitRepository) FetchAllStudentLimits(termID uint) ([]models.StudentLimit, error) {
+	var limits []models.StudentLimit
+	if err := r.db.Where("term_id = ?", termID).Order("student_id").Find(&limits).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return limits, nil
+}
+
+// SaveStudentLimit inserts or replaces a student's limit override
+func (r *StudentLimitRepository) SaveStudentLimit(limit *models.StudentLimit) error {
+	if err := r.db.Save(limit).Error; err != nil {
+		return fmt.Errorf("save failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteStudentLimit removes a student's override; deleting a missing override is not an error
+func (r *StudentLimitRepository) DeleteStudentLimit(termID uint, studentID uint) error {
+	if err := r.db.Where("term_id = ? AND student_id = ?", termID, studentID).Delete(&models.StudentLimit{}).Error; err != nil {
+		return fmt.Errorf("delete failed: %w", err)
+	}
+	return nil
+}
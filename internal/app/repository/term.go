@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type TermRepository struct {
+	db *gorm.DB
+}
+
+func NewTermRepository(db *gorm.DB) *TermRepository {
+	return &TermRepository{db: db}
+}
+
+func (r *TermRepository) FetchAllTerms() ([]models.Term, error) {
+	var terms []models.Term
+	if err := r.db.Order("id").Find(&terms).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return terms, nil
+}
+
+// FetchActiveTerm returns the active term, or gorm.ErrRecordNotFound if there is none
+func (r *TermRepository) FetchActiveTerm() (*models.Term, error) {
+	var term models.Term
+	if err := r.db.Where("active = ?", true).Take(&term).Error; err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (r *TermRepository) FetchTerm(termID uint) (*models.Term, error) {
+	var term models.Term
+	if err := r.db.Take(&term, termID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrTermNotFound
+		}
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return &term, nil
+}
+
+// InsertTerm creates a term together with its registration config, courses, the rules of those courses and
+// its student roster. rules is given the created courses with their IDs and returns the rules to create;
+// it may be nil.
+func (r *TermRepository) InsertTerm(term *models.Term, config *models.RegistrationConfig, courses []models.Course, studentIDs []uint, rules func(created []models.Course) models.CourseRules) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(term).Error; err != nil {
+			return fmt.Errorf("create term failed: %w", err)
+		}
+		config.TermID = term.ID
+		if err := tx.Create(config).Error; err != nil {
+			return fmt.Errorf("create config failed: %w", err)
+		}
+
+		if len(courses) > 0 {
+			for i := range courses {
+				courses[i].TermID = term.ID
+			}
+			if err := tx.CreateInBatches(courses, courseBatchSize).Error; err != nil {
+				return fmt.Errorf("create courses failed: %w", err)
+			}
+		}
+
+		if rules != nil {
+			if err := insertCourseRules(tx, rules(courses)); err != nil {
+				return err
+			}
+		}
+
+		if len(studentIDs) > 0 {
+			roster := make([]models.TermStudent, len(studentIDs))
+			for i, studentID := range studentIDs {
+				roster[i] = models.TermStudent{TermID: term.ID, StudentID: studentID}
+			}
+			if err := tx.CreateInBatches(roster, studentBatchSize).Error; err != nil {
+				return fmt.Errorf("create roster failed: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func insertCourseRules(tx *gorm.DB, rules models.CourseRules) error {
+	if len(rules.CourseGroups) > 0 {
+		if err := tx.Create(&rules.CourseGroups).Error; err != nil {
+			return fmt.Errorf("create course groups failed: %w", err)
+		}
+	}
+	if len(rules.SpecialRules) > 0 {
+		if err := tx.Create(&rules.SpecialRules).Error; err != nil {
+			return fmt.Errorf("create special course rules failed: %w", err)
+		}
+	}
+	if len(rules.EligibilityRules) > 0 {
+		if err := tx.Create(&rules.EligibilityRules).Error; err != nil {
+			return fmt.Errorf("create eligibility rules failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *TermRepository) FetchTermStudentIDs(termID uint) ([]uint, error) {
+	var studentIDs []uint
+	if err := r.db.Model(&models.TermStudent{}).Where("term_id = ?", termID).Order("student_id").Pluck("student_id", &studentIDs).Error; err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+	return studentIDs, nil
+}
+
+// SetActiveTerm makes termID the only active term
+func (r *TermRepository) SetActiveTerm(termID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Term{}).Where("active = ?", true).Update("active", false).Error; err != nil {
+			return fmt.Errorf("deactivate failed: %w", err)
+		}
+		result := tx.Model(&models.Term{}).Where("id = ?", termID).Update("active", true)
+		if result.Error != nil {
+			return fmt.Errorf("activate failed: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return e.ErrTermNotFound
+		}
+		return nil
+	})
+}
+
+// InsertTermAdoptingRows creates a term and moves the term-scoped rows created before terms existed into it;
+// every existing student is put on its roster
+func (r *TermRepository) InsertTermAdoptingRows(term *models.Term) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(term).Error; err != nil {
+			return fmt.Errorf("create term failed: %w", err)
+		}
+		termScoped := []interface{}{
+			&models.Course{},
+			&models.Enrollment{},
+			&models.RegistrationConfig{},
+			&models.RegistrationRound{},
+			&models.LotteryApplication{},
+			&models.LotteryRun{},
+			&models.CartItem{},
+			&models.CoursePreference{},
+			&models.StudentLimit{},
+		}
+		for _, model := range termScoped {
+			if err := tx.Model(model).Where("term_id = ?", 0).Update("term_id", term.ID).Error; err != nil {
+				return fmt.Errorf("adopt rows failed: %w", err)
+			}
+		}
+		if err := tx.Exec("INSERT INTO term_students (term_id, student_id) SELECT ?, id FROM students", term.ID).Error; err != nil {
+			return fmt.Errorf("adopt students failed: %w", err)
+		}
+		return nil
+	})
+}
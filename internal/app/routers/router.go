@@ -1,6 +1,8 @@
 package routers
 
 import (
+	"time"
+
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/memstore"
 	"github.com/gin-gonic/gin"
@@ -13,6 +15,7 @@ import (
 // InitRouter initialize routing information
 func InitRouter(
 	runMode, sessionKey string,
+	workerRequestTimeout time.Duration,
 	h *handler.Handlers,
 ) *gin.Engine {
 	gin.SetMode(runMode) // set gin mode (must be called before gin.New())
@@ -42,8 +45,86 @@ func InitRouter(
 			admin.GET("/registration/state", h.Admin.GetRegistrationState)
 			admin.POST("/registration/start", h.Admin.StartRegistration)
 			admin.POST("/registration/pause", h.Admin.PauseRegistration)
+			admin.POST("/registration/close", h.Admin.CloseRegistration)
+			admin.POST("/registration/finalize", h.Admin.FinalizeRegistration)
+			admin.POST("/registration/prepare", h.Admin.PrepareRegistration)
 			admin.PUT("/registration/period", h.Admin.SetRegistrationPeriod)
 			admin.GET("/registration/period", h.Admin.GetRegistrationPeriod)
+			admin.PUT("/registration/mode", h.Admin.SetRegistrationMode)
+
+			terms := admin.Group("/terms")
+			{
+				terms.GET("", h.Admin.GetTerms)
+				terms.POST("", h.Admin.CreateTerm)
+				terms.PUT("/active", h.Admin.ActivateTerm)
+				terms.POST("/rollover/preview", h.Admin.PreviewTermRollover)
+				terms.POST("/rollover", h.Admin.RolloverTerm)
+				terms.GET("/:term_id/courses", h.Admin.GetTermCourses)
+				terms.GET("/:term_id/enrollments", h.Admin.GetTermEnrollments)
+			}
+
+			lottery := admin.Group("/lottery")
+			{
+				lottery.POST("/draw", h.Admin.DrawLottery)
+				lottery.GET("/runs", h.Admin.GetLotteryRuns)
+				lottery.GET("/runs/:run_id", h.Admin.GetLotteryResults)
+			}
+
+			preference := admin.Group("/preference")
+			{
+				preference.POST("/preview", h.Admin.PreviewPreferenceAssignment)
+				preference.POST("/commit", h.Admin.CommitPreferenceAssignment)
+			}
+
+			rounds := admin.Group("/rounds")
+			{
+				rounds.GET("", h.Admin.GetRounds)
+				rounds.POST("", h.Admin.CreateRound)
+				rounds.PUT("/:round_id", h.Admin.UpdateRound)
+				rounds.DELETE("/:round_id", h.Admin.DeleteRound)
+				rounds.PUT("/:round_id/students", h.Admin.SetRoundStudents)
+			}
+
+			cohorts := admin.Group("/cohorts")
+			{
+				cohorts.GET("", h.Admin.GetCohorts)
+				cohorts.PUT("", h.Admin.SetCohorts)
+				cohorts.PUT("/assign", h.Admin.AssignCohorts)
+				cohorts.POST("/assign-random", h.Admin.AssignRandomCohorts)
+			}
+
+			limits := admin.Group("/limits")
+			{
+				limits.GET("", h.Admin.GetLimits)
+				limits.PUT("", h.Admin.SetDefaultLimit)
+				limits.PUT("/students/:student_id", h.Admin.SetStudentLimit)
+				limits.DELETE("/students/:student_id", h.Admin.DeleteStudentLimit)
+			}
+
+			eligibility := admin.Group("/eligibility")
+			{
+				eligibility.GET("/rules", h.Admin.GetEligibilityRules)
+				eligibility.POST("/rules", h.Admin.CreateEligibilityRule)
+				eligibility.DELETE("/rules/:rule_id", h.Admin.DeleteEligibilityRule)
+				eligibility.GET("/groups", h.Admin.GetStudentGroups)
+				eligibility.PUT("/groups/:group_name", h.Admin.SetStudentGroup)
+				eligibility.POST("/completions", h.Admin.ImportCompletions)
+				eligibility.POST("/completions/from-enrollments", h.Admin.RecordCompletionsFromEnrollments)
+			}
+
+			prerequisites := admin.Group("/prerequisites")
+			{
+				prerequisites.GET("", h.Admin.GetPrerequisites)
+				prerequisites.PUT("/:course_id", h.Admin.SetPrerequisites)
+			}
+
+			special := admin.Group("/special-courses")
+			{
+				special.GET("", h.Admin.GetSpecialCourseRules)
+				special.PUT("/limit", h.Admin.SetSpecialCourseLimit)
+				special.PUT("/:course_id/eligibility", h.Admin.SetSpecialCourseRule)
+				special.DELETE("/:course_id/eligibility", h.Admin.DeleteSpecialCourseRule)
+			}
 
 			setup := admin.Group("/setup")
 			{
@@ -59,11 +140,16 @@ func InitRouter(
 				setup.DELETE("/courses/reset", h.Admin.ResetCourses)
 
 				setup.DELETE("/enrollments/reset", h.Admin.ResetEnrollments)
+
+				setup.GET("/course-groups", h.Admin.GetCourseGroups)
+				setup.POST("/course-groups", h.Admin.CreateCourseGroup)
+				setup.DELETE("/course-groups/:group_id", h.Admin.DeleteCourseGroup)
 			}
 
-			// 수강 신청 기간 중에는 어떻게?
-			// admin.POST("/enrollments", adminHandler.AddEnrollment)
-			// admin.DELETE("/enrollments", adminHandler.CancelEnrollment)
+			// 수강 신청 기간 중에는 worker를 거쳐 처리
+			admin.POST("/enrollments", middleware.Timeout(workerRequestTimeout), h.Admin.ForceEnroll)
+			admin.DELETE("/enrollments", middleware.Timeout(workerRequestTimeout), h.Admin.ForceCancel)
+			admin.GET("/enrollments/logs", h.Admin.GetAdminEnrollmentLogs)
 		}
 
 		user := v1.Group("/courses")
@@ -76,13 +162,31 @@ func InitRouter(
 		}
 
 		courseReg := v1.Group("/course-reg")
-		courseReg.Use(middleware.AuthStudent())
+		courseReg.Use(middleware.AuthStudent(), middleware.Timeout(workerRequestTimeout))
 		{
 			courseReg.POST("/enrollment", h.CourseReg.EnrollCourse)
-			// courseReg.DELETE("/:course_id/enroll", courseRegHandler.CancelEnrollment)
+			courseReg.DELETE("/:course_id/enroll", h.CourseReg.CancelEnrollment)
+			courseReg.POST("/swap", h.CourseReg.SwapCourse)
+
+			courseReg.POST("/:course_id/waitlist", h.CourseReg.AddToWaitlist)
+			courseReg.DELETE("/:course_id/waitlist", h.CourseReg.DeleteFromWaitlist)
+
+			courseReg.POST("/:course_id/hold", h.CourseReg.HoldSeat)
+			courseReg.POST("/:course_id/hold/confirm", h.CourseReg.ConfirmHold)
+			courseReg.DELETE("/:course_id/hold", h.CourseReg.ReleaseHold)
+
+			courseReg.GET("/applications", h.CourseReg.GetApplications)
+			courseReg.POST("/applications", h.CourseReg.ApplyCourse)
+			courseReg.DELETE("/applications/:course_id", h.CourseReg.WithdrawApplication)
+
+			courseReg.GET("/cart", h.CourseReg.GetCart)
+			courseReg.POST("/cart", h.CourseReg.AddToCart)
+			courseReg.DELETE("/cart/:course_id", h.CourseReg.RemoveFromCart)
+			courseReg.POST("/cart/submit", h.CourseReg.SubmitCart)
 
-			// courseReg.POST("/:course_id/waitlist", courseRegHandler.AddToWaitlist)
-			// courseReg.DELETE("/:course_id/waitlist", courseRegHandler.DeleteToWaitlist)
+			courseReg.GET("/preference", h.CourseReg.GetPreference)
+			courseReg.PUT("/preference", h.CourseReg.SubmitPreference)
+			courseReg.DELETE("/preference", h.CourseReg.WithdrawPreference)
 		}
 	}
 	return r
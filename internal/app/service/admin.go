@@ -1,23 +1,51 @@
 package service
 
 import (
+	"fmt"
 	"log"
+	"sync"
 
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
 	"course-reg/internal/app/domain/export"
+	"course-reg/internal/app/domain/idempotency"
 	"course-reg/internal/app/domain/registration"
 	"course-reg/internal/app/domain/worker"
 	"course-reg/internal/app/models"
 	"course-reg/internal/app/repository"
+	"course-reg/internal/pkg/utils"
 )
 
+// dataEditablePhases are the phases in which students and courses can be added.
+// The worker is not running in these phases and reloads everything from the DB when registration opens.
+// Destructive operations (reset, delete) are only allowed in SETUP.
+var dataEditablePhases = []registration.Phase{registration.PhaseSetup, registration.PhasePaused, registration.PhaseClosed}
+
 type AdminService struct {
-	studentRepo   repository.StudentRepositoryInterface
-	courseRepo    repository.CourseRepositoryInterface
-	enrollRepo   repository.EnrollmentRepositoryInterface
-	regConfigRepo repository.RegistrationConfigRepositoryInterface
-	enrollWorker  *worker.EnrollmentWorker
-	regState      *registration.State
-	warmup        func()
+	studentRepo      repository.StudentRepositoryInterface
+	courseRepo       repository.CourseRepositoryInterface
+	enrollRepo       repository.EnrollmentRepositoryInterface
+	regConfigRepo    repository.RegistrationConfigRepositoryInterface
+	roundRepo        repository.RegistrationRoundRepositoryInterface
+	cohortRepo       repository.CohortRepositoryInterface
+	lotteryRepo      repository.LotteryRepositoryInterface
+	preferenceRepo   repository.PreferenceRepositoryInterface
+	adminLogRepo     repository.AdminEnrollmentLogRepositoryInterface
+	studentLimitRepo repository.StudentLimitRepositoryInterface
+	specialRuleRepo  repository.SpecialCourseRuleRepositoryInterface
+	courseGroupRepo  repository.CourseGroupRepositoryInterface
+	eligibilityRepo  repository.EligibilityRepositoryInterface
+	completionRepo   repository.CompletionRepositoryInterface
+	prerequisiteRepo repository.PrerequisiteRepositoryInterface
+	termRepo         repository.TermRepositoryInterface
+	enrollWorker     *worker.EnrollmentWorker
+	regState         *registration.State
+	idempotencyStore *idempotency.Store
+	clock            utils.TimeProvider
+	warmup           func()
+
+	detachedMu sync.Mutex               // serializes admin enrollment changes and data edits while the worker is not running
+	detached   *worker.EnrollmentWorker // runs admin enrollment changes while the worker is not running; nil until needed
 }
 
 func NewAdminService(
@@ -25,86 +53,205 @@ func NewAdminService(
 	c repository.CourseRepositoryInterface,
 	e repository.EnrollmentRepositoryInterface,
 	rc repository.RegistrationConfigRepositoryInterface,
+	rr repository.RegistrationRoundRepositoryInterface,
+	ch repository.CohortRepositoryInterface,
+	l repository.LotteryRepositoryInterface,
+	p repository.PreferenceRepositoryInterface,
+	al repository.AdminEnrollmentLogRepositoryInterface,
+	sl repository.StudentLimitRepositoryInterface,
+	sr repository.SpecialCourseRuleRepositoryInterface,
+	cg repository.CourseGroupRepositoryInterface,
+	el repository.EligibilityRepositoryInterface,
+	cp repository.CompletionRepositoryInterface,
+	pr repository.PrerequisiteRepositoryInterface,
+	t repository.TermRepositoryInterface,
 	w *worker.EnrollmentWorker,
 	rs *registration.State,
+	idem *idempotency.Store,
+	clock utils.TimeProvider,
 	warmup func(),
 ) *AdminService {
 	return &AdminService{
-		studentRepo:   s,
-		courseRepo:    c,
-		enrollRepo:   e,
-		regConfigRepo: rc,
-		enrollWorker:  w,
-		regState:      rs,
-		warmup:        warmup,
+		studentRepo:      s,
+		courseRepo:       c,
+		enrollRepo:       e,
+		regConfigRepo:    rc,
+		roundRepo:        rr,
+		cohortRepo:       ch,
+		lotteryRepo:      l,
+		preferenceRepo:   p,
+		adminLogRepo:     al,
+		studentLimitRepo: sl,
+		specialRuleRepo:  sr,
+		courseGroupRepo:  cg,
+		eligibilityRepo:  el,
+		completionRepo:   cp,
+		prerequisiteRepo: pr,
+		termRepo:         t,
+		enrollWorker:     w,
+		regState:         rs,
+		idempotencyStore: idem,
+		clock:            clock,
+		warmup:           warmup,
 	}
 }
 
-func (s *AdminService) GetRegistrationState() bool {
-	return s.regState.IsEnabled()
+func (s *AdminService) GetRegistrationState() registration.Phase {
+	return s.regState.Phase()
 }
 
 func (s *AdminService) StartRegistration() error {
-	err := s.regState.ChangeEnabledAndAct(true, func() error {
-		if s.warmup != nil {
-			s.warmup()
-		}
-		students, err := s.studentRepo.FetchAllStudents()
-		if err != nil {
-			log.Println("failed to load students:", err.Error())
-			return err
-		}
+	return s.changePhase(registration.PhaseOpen)
+}
 
-		courses, err := s.courseRepo.FetchAllCourses()
-		if err != nil {
-			log.Println("failed to load courses:", err.Error())
-			return err
-		}
+func (s *AdminService) PauseRegistration() error {
+	return s.changePhase(registration.PhasePaused)
+}
 
-		enrollments, err := s.enrollRepo.FetchAllEnrollments()
-		if err != nil {
-			log.Printf("failed to load enrollments: %v", err)
-			return err
-		}
+func (s *AdminService) CloseRegistration() error {
+	return s.changePhase(registration.PhaseClosed)
+}
 
-		if err := s.enrollWorker.Start(students, courses, enrollments); err != nil {
-			log.Printf("failed to start worker: %v", err)
-			return err
+func (s *AdminService) FinalizeRegistration() error {
+	return s.changePhase(registration.PhaseFinalized)
+}
+
+// PrepareRegistration moves a finalized registration back to setup for the next run
+func (s *AdminService) PrepareRegistration() error {
+	return s.changePhase(registration.PhaseSetup)
+}
+
+// changePhase moves the registration to the next phase, starting the worker when entering OPEN
+// and stopping it when leaving OPEN. Idempotency keys are forgotten on CLOSED.
+// The new phase is persisted so it survives restarts.
+func (s *AdminService) changePhase(next registration.Phase) error {
+	err := s.regState.TransitionAndAct(next, func(prev registration.Phase) error {
+		s.StopDetachedWorker()
+
+		if next == registration.PhaseOpen {
+			if err := s.startWorker(); err != nil {
+				return err
+			}
+			if err := s.regConfigRepo.UpdatePhase(s.regState.Term(), string(next)); err != nil {
+				log.Println("save registration phase failed:", err.Error())
+				s.enrollWorker.Stop()
+				return err
+			}
+			return nil
 		}
 
-		if err := s.regConfigRepo.UpdateEnabled(true); err != nil {
-			log.Println("save registration state failed:", err.Error())
+		if err := s.regConfigRepo.UpdatePhase(s.regState.Term(), string(next)); err != nil {
+			log.Println("save registration phase failed:", err.Error())
 			return err
 		}
-
+		if prev == registration.PhaseOpen {
+			s.enrollWorker.Stop()
+		}
+		if next == registration.PhaseClosed {
+			s.idempotencyStore.Reset() // the registration window is over
+		}
 		return nil
 	})
 
 	if err != nil {
-		log.Println(err)
+		log.Printf("change registration phase to %s failed: %v", next, err)
 	} else {
-		log.Println("[info] Start Registration!!!")
+		log.Printf("[info] registration phase changed to %s", next)
 	}
-
 	return err
 }
 
-func (s *AdminService) PauseRegistration() error {
-	err := s.regState.ChangeEnabledAndAct(false, func() error {
-		s.enrollWorker.Stop()
+// editData runs act, which changes data the enrollment cache is loaded from, if the phase is one of phases.
+// The detached worker is dropped first, so its journaled rows are saved before act and the next admin
+// enrollment change loads the new data.
+func (s *AdminService) editData(act func() error, phases ...registration.Phase) error {
+	return s.regState.RunInPhase(func() error {
+		s.detachedMu.Lock()
+		defer s.detachedMu.Unlock()
+		s.dropDetachedWorker()
+		return act()
+	}, phases...)
+}
 
-		if err := s.regConfigRepo.UpdateEnabled(false); err != nil {
-			log.Println("save registration state failed:", err.Error())
-			return err
-		}
-		return nil
-	})
+func (s *AdminService) startWorker() error {
+	if s.warmup != nil {
+		s.warmup()
+	}
+	return s.startWorkerFromDB(s.enrollWorker)
+}
+
+// startWorkerFromDB loads everything the worker's cache needs and starts it
+func (s *AdminService) startWorkerFromDB(w *worker.EnrollmentWorker) error {
+	data, err := s.loadInitData()
 	if err != nil {
-		log.Println(err)
-	} else {
-		log.Println("Pause Registration!!!")
+		return err
 	}
-	return err
+
+	if err := w.Start(data); err != nil {
+		log.Printf("failed to start worker: %v", err)
+		return err
+	}
+	return nil
+}
+
+// loadInitData loads everything an enrollment cache is built from
+func (s *AdminService) loadInitData() (cache.InitData, error) {
+	var data cache.InitData
+	var err error
+	data.TermID = s.regState.Term()
+
+	if data.Students, err = s.studentRepo.FetchTermStudents(data.TermID); err != nil {
+		log.Println("failed to load students:", err.Error())
+		return data, err
+	}
+
+	if data.Courses, err = s.courseRepo.FetchAllCourses(data.TermID); err != nil {
+		log.Println("failed to load courses:", err.Error())
+		return data, err
+	}
+
+	if data.Enrollments, err = s.enrollRepo.FetchAllEnrollments(data.TermID); err != nil {
+		log.Printf("failed to load enrollments: %v", err)
+		return data, err
+	}
+
+	if data.DefaultLimit, data.StudentLimits, err = s.loadLimits(); err != nil {
+		log.Printf("failed to load limits: %v", err)
+		return data, err
+	}
+
+	if data.MaxSpecialCourses, data.SpecialRules, err = s.GetSpecialCourseRules(); err != nil {
+		log.Printf("failed to load special course rules: %v", err)
+		return data, err
+	}
+
+	if data.CourseGroups, err = s.courseGroupRepo.FetchAllGroups(data.TermID); err != nil {
+		log.Printf("failed to load course groups: %v", err)
+		return data, err
+	}
+
+	if data.EligibilityRules, err = s.eligibilityRepo.FetchAllRules(data.TermID); err != nil {
+		log.Printf("failed to load eligibility rules: %v", err)
+		return data, err
+	}
+
+	if data.StudentGroups, err = s.eligibilityRepo.FetchAllGroupMembers(); err != nil {
+		log.Printf("failed to load student groups: %v", err)
+		return data, err
+	}
+
+	if data.Completions, err = s.completionRepo.FetchAllCompletions(); err != nil {
+		log.Printf("failed to load course completions: %v", err)
+		return data, err
+	}
+	data.EligibilityDate = s.clock.Now()
+
+	if data.Prerequisites, err = s.prerequisiteRepo.FetchAllPrerequisites(); err != nil {
+		log.Printf("failed to load prerequisites: %v", err)
+		return data, err
+	}
+
+	return data, nil
 }
 
 func (s *AdminService) GetRegistrationPeriod() (string, string) {
@@ -113,76 +260,86 @@ func (s *AdminService) GetRegistrationPeriod() (string, string) {
 
 func (s *AdminService) SetRegistrationPeriod(startTime, endTime string) error {
 	// Validate time format
-	// _, err := utils.StringToTime(startTime)
-	// if err != nil {
-	// 	log.Println("invalid start time format:", err.Error())
-	// 	return err
-	// }
-
-	// _, err = utils.StringToTime(endTime)
-	// if err != nil {
-	// 	log.Println("invalid end time format:", err.Error())
-	// 	return err
-	// }
-
-	// // Update in-memory state
-	// s.regState.SetPeriod(startTime, endTime)
-
-	// // Save to DB
-	// if err := s.regConfigRepo.UpdatePeriod(startTime, endTime); err != nil {
-	// 	log.Println("failed to save registration period:", err.Error())
-	// 	return err
-	// }
+	start, err := utils.StringToTime(startTime)
+	if err != nil {
+		log.Println("invalid start time format:", err.Error())
+		return fmt.Errorf("%w: %v", e.ErrInvalidInput, err)
+	}
+
+	end, err := utils.StringToTime(endTime)
+	if err != nil {
+		log.Println("invalid end time format:", err.Error())
+		return fmt.Errorf("%w: %v", e.ErrInvalidInput, err)
+	}
+
+	if !start.Before(end) {
+		log.Println("invalid registration period: start time must be before end time")
+		return fmt.Errorf("%w: start time must be before end time", e.ErrInvalidInput)
+	}
 
+	// Save to DB
+	if err := s.regConfigRepo.UpdatePeriod(s.regState.Term(), startTime, endTime); err != nil {
+		log.Println("failed to save registration period:", err.Error())
+		return err
+	}
+
+	// Update in-memory state (the scheduler picks it up on its next tick)
+	s.regState.SetPeriod(startTime, endTime)
+
+	log.Printf("[info] registration period set: %s ~ %s", startTime, endTime)
 	return nil
 }
 
 func (s *AdminService) RegisterStudents(students []models.Student) error {
-	err := s.regState.RunIfEnabled(false, func() error {
-		return s.studentRepo.BatchInsertStudents(students)
-	})
+	err := s.editData(func() error {
+		return s.studentRepo.BatchInsertStudents(s.regState.Term(), students)
+	}, dataEditablePhases...)
 	if err != nil {
 		log.Println("register students failed:", err.Error())
 		return err
 	}
-	return nil
+	return s.ReloadEntryOffsets()
 }
 
+// ResetStudents takes every student off the active term's roster. Students stay registered, keeping their IDs
+// and the history of other terms; registering them again puts them back on the roster.
 func (s *AdminService) ResetStudents() error {
-	err := s.regState.RunIfEnabled(false, func() error {
-		return s.studentRepo.DeleteAllStudents()
-	})
+	err := s.editData(func() error {
+		return s.studentRepo.DeleteTermStudents(s.regState.Term())
+	}, registration.PhaseSetup)
 	if err != nil {
 		log.Println("reset students failed:", err.Error())
 		return err
 	}
 
-	return nil
+	return s.ReloadEntryOffsets()
 }
 
 func (s *AdminService) CreateCourse(course *models.Course) (uint, error) {
-	err := s.regState.RunIfEnabled(false, func() error {
+	err := s.editData(func() error {
+		course.TermID = s.regState.Term()
+		defaultCode(course)
 		return s.courseRepo.InsertCourse(course)
-	})
+	}, dataEditablePhases...)
 	if err != nil {
 		log.Println("create course failed:", err.Error())
 		return 0, err
 	}
 
-	export.ExportCoursesToJson(s.courseRepo)
+	export.ExportCoursesToJson(s.courseRepo, s.regState.Term())
 	return course.ID, nil
 }
 
 func (s *AdminService) DeleteCourse(courseID uint) error {
-	err := s.regState.RunIfEnabled(false, func() error {
-		return s.courseRepo.DeleteCourse(courseID)
-	})
+	err := s.editData(func() error {
+		return s.courseRepo.DeleteCourse(s.regState.Term(), courseID)
+	}, registration.PhaseSetup)
 	if err != nil {
 		log.Println("delete course failed:", err.Error())
 		return err
 	}
 
-	export.ExportCoursesToJson(s.courseRepo)
+	export.ExportCoursesToJson(s.courseRepo, s.regState.Term())
 	return nil
 }
 
@@ -190,36 +347,48 @@ func (s *AdminService) RegisterCourses(courses []models.Course) error {
 	// todo: course가 없을 때만 실행 가능하도록?
 	// todo: shcedule에 대한 validation?
 
-	err := s.regState.RunIfEnabled(false, func() error {
+	err := s.editData(func() error {
+		termID := s.regState.Term()
+		for i := range courses {
+			courses[i].TermID = termID
+			defaultCode(&courses[i])
+		}
 		return s.courseRepo.BatchInsertCourses(courses)
-	})
+	}, dataEditablePhases...)
 	if err != nil {
 		log.Println("register courses failed:", err.Error())
 		return err
 	}
 
-	export.ExportCoursesToJson(s.courseRepo)
+	export.ExportCoursesToJson(s.courseRepo, s.regState.Term())
 	return nil
 }
 
+// defaultCode gives a course registered without a code its name as the code
+func defaultCode(course *models.Course) {
+	if course.Code == "" {
+		course.Code = course.Name
+	}
+}
+
 func (s *AdminService) ResetCourses() error {
-	err := s.regState.RunIfEnabled(false, func() error {
-		return s.courseRepo.DeleteAllCourses()
-	})
+	err := s.editData(func() error {
+		return s.courseRepo.DeleteAllCourses(s.regState.Term())
+	}, registration.PhaseSetup)
 	if err != nil {
 		log.Println("reset courses failed:", err.Error())
 		return err
 	}
 
-	export.ExportCoursesToJson(s.courseRepo)
+	export.ExportCoursesToJson(s.courseRepo, s.regState.Term())
 	return nil
 }
 
 func (s *AdminService) ResetEnrollments() error {
-	err := s.regState.RunIfEnabled(false, func() error {
+	err := s.editData(func() error {
 		log.Println("reset enrollments!!")
-		return s.enrollRepo.DeleteAllEnrollments()
-	})
+		return s.enrollWorker.DeleteAllEnrollments(s.regState.Term())
+	}, registration.PhaseSetup)
 	if err != nil {
 		log.Println("reset enrollments failed:", err.Error())
 		return err
@@ -229,6 +398,4 @@ func (s *AdminService) ResetEnrollments() error {
 }
 
 // func (s *AdminService) GetEnrolledStudentsByCourse(courseID uint) ([]Student, error)
-// func (s *AdminService) ForceEnrollStudent(courseID uint, studentID uint) error
-// func (s *AdminService) CancelEnrollment(courseID uint, studentID uint) error
 // func (s *AdminService) CheckDuplicateCourses(studentID uint, courseID uint) ([]Course, error)
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func (s *AdminService) GetCohorts() ([]models.Cohort, error) {
+	return s.cohortRepo.FetchAllCohorts()
+}
+
+// SetCohorts replaces all cohort definitions
+func (s *AdminService) SetCohorts(cohorts []models.Cohort) error {
+	names := make(map[string]struct{}, len(cohorts))
+	for _, cohort := range cohorts {
+		if cohort.Name == "" || cohort.EntryOffsetMinutes < 0 {
+			return fmt.Errorf("%w: invalid cohort %+v", e.ErrInvalidInput, cohort)
+		}
+		if _, dup := names[cohort.Name]; dup {
+			return fmt.Errorf("%w: duplicate cohort %q", e.ErrInvalidInput, cohort.Name)
+		}
+		names[cohort.Name] = struct{}{}
+	}
+
+	err := s.editData(func() error {
+		return s.cohortRepo.ReplaceCohorts(cohorts)
+	}, scheduleEditablePhases...)
+	if err != nil {
+		log.Println("set cohorts failed:", err.Error())
+		return err
+	}
+
+	return s.ReloadEntryOffsets()
+}
+
+// AssignCohorts sets the cohort of each given student (studentID -> cohort name, "" for none)
+func (s *AdminService) AssignCohorts(assignments map[uint]string) error {
+	if err := s.validateCohortNames(assignments); err != nil {
+		log.Println("assign cohorts failed:", err.Error())
+		return err
+	}
+
+	err := s.editData(func() error {
+		return s.studentRepo.UpdateCohorts(assignments)
+	}, scheduleEditablePhases...)
+	if err != nil {
+		log.Println("assign cohorts failed:", err.Error())
+		return err
+	}
+
+	return s.ReloadEntryOffsets()
+}
+
+// AssignRandomCohorts spreads all students evenly over the given cohorts in a seeded random order
+func (s *AdminService) AssignRandomCohorts(cohortNames []string, seed int64) (map[uint]string, error) {
+	if len(cohortNames) == 0 {
+		return nil, fmt.Errorf("%w: no cohorts given", e.ErrInvalidInput)
+	}
+
+	students, err := s.studentRepo.FetchAllStudents()
+	if err != nil {
+		log.Println("failed to load students:", err.Error())
+		return nil, err
+	}
+
+	// Sort first so the same seed always gives the same assignment
+	sort.Slice(students, func(i, j int) bool { return students[i].ID < students[j].ID })
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(students), func(i, j int) { students[i], students[j] = students[j], students[i] })
+
+	assignments := make(map[uint]string, len(students))
+	for i, student := range students {
+		assignments[student.ID] = cohortNames[i%len(cohortNames)]
+	}
+
+	if err := s.AssignCohorts(assignments); err != nil {
+		return nil, err
+	}
+	log.Printf("[info] assigned %d students to %d cohorts (seed: %d)", len(students), len(cohortNames), seed)
+	return assignments, nil
+}
+
+// ReloadEntryOffsets loads each student's cohort entry offset into the registration state
+func (s *AdminService) ReloadEntryOffsets() error {
+	cohorts, err := s.cohortRepo.FetchAllCohorts()
+	if err != nil {
+		log.Println("failed to load cohorts:", err.Error())
+		return err
+	}
+
+	students, err := s.studentRepo.FetchAllStudents()
+	if err != nil {
+		log.Println("failed to load students:", err.Error())
+		return err
+	}
+
+	cohortOffsets := make(map[string]time.Duration, len(cohorts))
+	for _, cohort := range cohorts {
+		cohortOffsets[cohort.Name] = time.Duration(cohort.EntryOffsetMinutes) * time.Minute
+	}
+
+	offsets := make(map[uint]time.Duration)
+	for _, student := range students {
+		if offset := cohortOffsets[student.Cohort]; offset > 0 {
+			offsets[student.ID] = offset
+		}
+	}
+
+	s.regState.SetEntryOffsets(offsets)
+	log.Printf("[info] loaded entry offsets for %d students", len(offsets))
+	return nil
+}
+
+func (s *AdminService) validateCohortNames(assignments map[uint]string) error {
+	cohorts, err := s.cohortRepo.FetchAllCohorts()
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]struct{}, len(cohorts))
+	for _, cohort := range cohorts {
+		known[cohort.Name] = struct{}{}
+	}
+	for studentID, name := range assignments {
+		if _, ok := known[name]; name != "" && !ok {
+			return fmt.Errorf("%w: unknown cohort %q for student %d", e.ErrInvalidInput, name, studentID)
+		}
+	}
+	return nil
+}
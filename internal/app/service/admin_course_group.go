@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+func (s *AdminService) GetCourseGroups() ([]models.CourseGroup, error) {
+	return s.courseGroupRepo.FetchAllGroups(s.regState.Term())
+}
+
+// CreateCourseGroup adds an exclusive or co-requisite group.
+// Groups are loaded into the worker's cache when registration opens.
+func (s *AdminService) CreateCourseGroup(group *models.CourseGroup) (uint, error) {
+	if !cache.IsValidGroupType(group.Type) {
+		return 0, fmt.Errorf("%w: unknown course group type %q", e.ErrInvalidInput, group.Type)
+	}
+	slices.Sort(group.CourseIDs)
+	group.CourseIDs = slices.Compact(group.CourseIDs)
+	if len(group.CourseIDs) < 2 {
+		return 0, fmt.Errorf("%w: a course group needs at least two courses", e.ErrInvalidInput)
+	}
+
+	group.ID = 0
+	err := s.editData(func() error {
+		courses, err := s.courseRepo.FetchCoursesByIDs(s.regState.Term(), group.CourseIDs)
+		if err != nil {
+			return err
+		}
+		if len(courses) != len(group.CourseIDs) {
+			return fmt.Errorf("%w: some of %v", e.ErrCourseNotFound, group.CourseIDs)
+		}
+		return s.courseGroupRepo.InsertGroup(group)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("create course group failed:", err.Error())
+		return 0, err
+	}
+
+	log.Printf("[info] course group created (id: %d, type: %s, courses: %v)", group.ID, group.Type, group.CourseIDs)
+	return group.ID, nil
+}
+
+func (s *AdminService) DeleteCourseGroup(groupID uint) error {
+	err := s.editData(func() error {
+		return s.courseGroupRepo.DeleteGroup(s.regState.Term(), groupID)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("delete course group failed:", err.Error())
+		return err
+	}
+	return nil
+}
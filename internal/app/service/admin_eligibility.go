@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/eligibility"
+	"course-reg/internal/app/models"
+)
+
+func (s *AdminService) GetEligibilityRules() ([]models.EligibilityRule, error) {
+	return s.eligibilityRepo.FetchAllRules(s.regState.Term())
+}
+
+// CreateEligibilityRule adds a rule to a course. Rules are loaded into the worker's cache when registration opens.
+func (s *AdminService) CreateEligibilityRule(rule *models.EligibilityRule) (uint, error) {
+	if _, err := eligibility.NewRule(*rule, s.clock.Now()); err != nil {
+		log.Println("create eligibility rule failed:", err.Error())
+		return 0, err
+	}
+
+	rule.ID = 0
+	err := s.editData(func() error {
+		exists, err := s.courseRepo.CourseExists(s.regState.Term(), rule.CourseID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: %d", e.ErrCourseNotFound, rule.CourseID)
+		}
+		return s.eligibilityRepo.InsertRule(rule)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("create eligibility rule failed:", err.Error())
+		return 0, err
+	}
+
+	log.Printf("[info] eligibility rule created (id: %d, course: %d, type: %s)", rule.ID, rule.CourseID, rule.Type)
+	return rule.ID, nil
+}
+
+func (s *AdminService) DeleteEligibilityRule(ruleID uint) error {
+	err := s.editData(func() error {
+		return s.eligibilityRepo.DeleteRule(s.regState.Term(), ruleID)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("delete eligibility rule failed:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// GetStudentGroups returns the members of every student group (group name -> studentIDs)
+func (s *AdminService) GetStudentGroups() (map[string][]uint, error) {
+	members, err := s.eligibilityRepo.FetchAllGroupMembers()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]uint)
+	for _, m := range members {
+		groups[m.GroupName] = append(groups[m.GroupName], m.StudentID)
+	}
+	return groups, nil
+}
+
+// SetStudentGroup replaces the members of a student group; an empty list removes the group
+func (s *AdminService) SetStudentGroup(groupName string, studentIDs []uint) error {
+	if strings.TrimSpace(groupName) == "" {
+		return fmt.Errorf("%w: group name is required", e.ErrInvalidInput)
+	}
+
+	err := s.editData(func() error {
+		return s.eligibilityRepo.ReplaceGroupMembers(groupName, studentIDs)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("set student group failed:", err.Error())
+		return err
+	}
+
+	log.Printf("[info] student group %q set (%d students)", groupName, len(studentIDs))
+	return nil
+}
+
+// ImportCompletions records courses students completed in previous terms; ones already recorded are skipped
+func (s *AdminService) ImportCompletions(completions []models.CourseCompletion) error {
+	err := s.editData(func() error {
+		return s.completionRepo.BatchInsertCompletions(completions)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("import completions failed:", err.Error())
+		return err
+	}
+
+	log.Printf("[info] imported %d course completions", len(completions))
+	return nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/domain/worker"
+	"course-reg/internal/app/models"
+)
+
+// adminEnrollPhases are the phases in which admins can change enrollments
+var adminEnrollPhases = []registration.Phase{registration.PhaseSetup, registration.PhaseOpen, registration.PhasePaused, registration.PhaseClosed}
+
+const (
+	adminActionEnroll = "ENROLL"
+	adminActionCancel = "CANCEL"
+)
+
+// ForceEnroll enrolls a student on behalf of an admin, skipping the rules set in overrides.
+// Every attempt is logged with the reason, whether it succeeds or not.
+func (s *AdminService) ForceEnroll(ctx context.Context, studentID, courseID uint, overrides worker.Overrides, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("%w: reason is required", e.ErrInvalidInput)
+	}
+
+	err := s.regState.RunInPhaseWith(func(phase registration.Phase) error {
+		if !overrides.BypassEligibility {
+			if err := s.regState.CheckRoundEligibility(s.clock.Now(), studentID); err != nil {
+				return err
+			}
+		}
+		return s.runOnWorker(phase, func(w *worker.EnrollmentWorker) error {
+			return w.AdminEnroll(ctx, studentID, courseID, overrides)
+		})
+	}, adminEnrollPhases...)
+
+	s.recordAdminAction(&models.AdminEnrollmentLog{
+		Action:             adminActionEnroll,
+		StudentID:          studentID,
+		CourseID:           courseID,
+		IgnoreCapacity:     overrides.IgnoreCapacity,
+		IgnoreTimeConflict: overrides.IgnoreTimeConflict,
+		IgnoreLimits:       overrides.IgnoreLimits,
+		BypassEligibility:  overrides.BypassEligibility,
+		IgnoreCourseGroups: overrides.IgnoreCourseGroups,
+		Reason:             reason,
+	}, err)
+	return err
+}
+
+// ForceCancel cancels a student's enrollment on behalf of an admin; the freed seat goes to the waitlist
+func (s *AdminService) ForceCancel(ctx context.Context, studentID, courseID uint, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("%w: reason is required", e.ErrInvalidInput)
+	}
+
+	err := s.regState.RunInPhaseWith(func(phase registration.Phase) error {
+		return s.runOnWorker(phase, func(w *worker.EnrollmentWorker) error {
+			return w.AdminCancel(ctx, studentID, courseID)
+		})
+	}, adminEnrollPhases...)
+
+	s.recordAdminAction(&models.AdminEnrollmentLog{
+		Action:    adminActionCancel,
+		StudentID: studentID,
+		CourseID:  courseID,
+		Reason:    reason,
+	}, err)
+	return err
+}
+
+func (s *AdminService) GetAdminEnrollmentLogs() ([]models.AdminEnrollmentLog, error) {
+	return s.adminLogRepo.FetchAllLogs()
+}
+
+// runOnWorker runs act on the enrollment worker while registration is open, so the cache stays consistent.
+// Otherwise the worker is stopped, and act runs on a detached worker loaded from the DB once and reused
+// until the phase, the term or the data it was loaded from changes.
+// Must be called within RunInPhaseWith so the phase cannot change meanwhile.
+func (s *AdminService) runOnWorker(phase registration.Phase, act func(w *worker.EnrollmentWorker) error) error {
+	if phase == registration.PhaseOpen {
+		return act(s.enrollWorker)
+	}
+
+	s.detachedMu.Lock()
+	defer s.detachedMu.Unlock()
+
+	if s.detached == nil {
+		detached := worker.NewEnrollmentWorker(1, 1, s.enrollRepo, s.enrollWorker.Journal(), s.clock)
+		if err := s.startWorkerFromDB(detached); err != nil {
+			return err
+		}
+		s.detached = detached
+	}
+	return act(s.detached)
+}
+
+// StopDetachedWorker stops the worker admin enrollment changes run on while registration is not open,
+// when the phase or term changes and on shutdown
+func (s *AdminService) StopDetachedWorker() {
+	s.detachedMu.Lock()
+	defer s.detachedMu.Unlock()
+	s.dropDetachedWorker()
+}
+
+// dropDetachedWorker stops the detached worker, saving its journaled rows; the next admin change loads a new one.
+// Must be called with detachedMu held.
+func (s *AdminService) dropDetachedWorker() {
+	if s.detached != nil {
+		s.detached.Stop()
+		s.detached = nil
+	}
+}
+
+func (s *AdminService) recordAdminAction(entry *models.AdminEnrollmentLog, err error) {
+	if err != nil {
+		entry.Error = err.Error()
+		log.Printf("admin %s failed (student: %d, course: %d): %v", strings.ToLower(entry.Action), entry.StudentID, entry.CourseID, err)
+	} else {
+		log.Printf("[info] admin %s (student: %d, course: %d, reason: %s)", strings.ToLower(entry.Action), entry.StudentID, entry.CourseID, entry.Reason)
+	}
+
+	if err := s.adminLogRepo.InsertLog(entry); err != nil {
+		log.Println("save admin enrollment log failed:", err.Error())
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/domain/worker"
+	"course-reg/internal/app/models"
+)
+
+// GetLimits returns the global limit and every per-student override
+func (s *AdminService) GetLimits() (cache.Limit, []models.StudentLimit, error) {
+	config, err := s.regConfigRepo.GetConfig(s.regState.Term())
+	if err != nil {
+		return cache.Limit{}, nil, err
+	}
+	overrides, err := s.studentLimitRepo.FetchAllStudentLimits(s.regState.Term())
+	if err != nil {
+		return cache.Limit{}, nil, err
+	}
+	return cache.Limit{MaxCourses: config.MaxCourses, MaxCredits: config.MaxCredits}, overrides, nil
+}
+
+// SetDefaultLimit sets the limit for students without an override.
+// Limits are loaded into the worker when registration opens, so they cannot change while it is open.
+func (s *AdminService) SetDefaultLimit(limit cache.Limit) error {
+	if err := validateLimit(limit); err != nil {
+		return err
+	}
+
+	err := s.editData(func() error {
+		return s.regConfigRepo.UpdateLimits(s.regState.Term(), limit.MaxCourses, limit.MaxCredits)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("set default limit failed:", err.Error())
+		return err
+	}
+
+	log.Printf("[info] default limit set (max courses: %d, max credits: %d)", limit.MaxCourses, limit.MaxCredits)
+	return nil
+}
+
+// SetStudentLimit overrides the limit of one student. Unlike the default limit it can change while
+// registration is open: the override is saved and applied to the worker, and holds for the student's next requests.
+func (s *AdminService) SetStudentLimit(studentID uint, limit cache.Limit) error {
+	if err := validateLimit(limit); err != nil {
+		return err
+	}
+
+	err := s.changeStudentLimit(studentID, &limit, func() error {
+		return s.studentLimitRepo.SaveStudentLimit(&models.StudentLimit{
+			TermID:     s.regState.Term(),
+			StudentID:  studentID,
+			MaxCourses: limit.MaxCourses,
+			MaxCredits: limit.MaxCredits,
+		})
+	})
+	if err != nil {
+		log.Println("set student limit failed:", err.Error())
+		return err
+	}
+
+	log.Printf("[info] student limit set (student: %d, max courses: %d, max credits: %d)", studentID, limit.MaxCourses, limit.MaxCredits)
+	return nil
+}
+
+// DeleteStudentLimit puts the student back on the default limit
+func (s *AdminService) DeleteStudentLimit(studentID uint) error {
+	err := s.changeStudentLimit(studentID, nil, func() error {
+		return s.studentLimitRepo.DeleteStudentLimit(s.regState.Term(), studentID)
+	})
+	if err != nil {
+		log.Println("delete student limit failed:", err.Error())
+		return err
+	}
+
+	log.Printf("[info] student limit removed (student: %d)", studentID)
+	return nil
+}
+
+// changeStudentLimit applies an override change to the worker, like admin enrollment changes, then saves it with save.
+// A busy worker rejects the change before anything is saved; the worker is asked without the caller's context,
+// so a change it accepted is always saved.
+func (s *AdminService) changeStudentLimit(studentID uint, limit *cache.Limit, save func() error) error {
+	return s.regState.RunInPhaseWith(func(phase registration.Phase) error {
+		return s.runOnWorker(phase, func(w *worker.EnrollmentWorker) error {
+			if err := w.SetStudentLimit(context.Background(), studentID, limit); err != nil {
+				return err
+			}
+			return save()
+		})
+	}, adminEnrollPhases...)
+}
+
+func (s *AdminService) loadLimits() (cache.Limit, map[uint]cache.Limit, error) {
+	defaultLimit, overrides, err := s.GetLimits()
+	if err != nil {
+		return cache.Limit{}, nil, err
+	}
+
+	studentLimits := make(map[uint]cache.Limit, len(overrides))
+	for _, override := range overrides {
+		studentLimits[override.StudentID] = cache.Limit{MaxCourses: override.MaxCourses, MaxCredits: override.MaxCredits}
+	}
+	return defaultLimit, studentLimits, nil
+}
+
+func validateLimit(limit cache.Limit) error {
+	if limit.MaxCourses < 0 || limit.MaxCredits < 0 {
+		return fmt.Errorf("%w: limits cannot be negative", e.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"course-reg/internal/app/domain/allocation"
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/models"
+)
+
+func (s *AdminService) GetRegistrationMode() registration.Mode {
+	return s.regState.Mode()
+}
+
+func (s *AdminService) SetRegistrationMode(mode registration.Mode) error {
+	if !mode.IsValid() {
+		return fmt.Errorf("%w: unknown registration mode %q", e.ErrInvalidInput, mode)
+	}
+
+	err := s.regState.ChangeModeAndAct(mode, func() error {
+		return s.regConfigRepo.UpdateMode(s.regState.Term(), string(mode))
+	})
+	if err != nil {
+		log.Println("set registration mode failed:", err.Error())
+		return err
+	}
+
+	log.Printf("[info] registration mode set to %s", mode)
+	return nil
+}
+
+// DrawLottery assigns seats to all pending applications once the application window is closed.
+// The draw is reproducible from its seed; a random seed is used when seed is nil.
+// Every application is consumed and its outcome recorded with the run.
+func (s *AdminService) DrawLottery(seed *int64, waitlistLosers bool) (*models.LotteryRun, error) {
+	opts := allocation.LotteryOptions{Seed: s.clock.Now().UnixNano(), WaitlistLosers: waitlistLosers}
+	if seed != nil {
+		opts.Seed = *seed
+	}
+
+	var run *models.LotteryRun
+	err := s.editData(func() error {
+		if err := s.regState.RequireMode(registration.ModeLottery); err != nil {
+			return err
+		}
+		var err error
+		run, err = s.drawLottery(opts)
+		return err
+	}, registration.PhaseClosed)
+	if err != nil {
+		log.Println("draw lottery failed:", err.Error())
+		return nil, err
+	}
+
+	log.Printf("[info] lottery run %d drawn (seed: %d, applications: %d, enrolled: %d, waitlisted: %d)",
+		run.ID, run.Seed, run.Applications, run.Enrolled, run.Waitlisted)
+	return run, nil
+}
+
+func (s *AdminService) drawLottery(opts allocation.LotteryOptions) (*models.LotteryRun, error) {
+	applications, err := s.lotteryRepo.FetchAllApplications(s.regState.Term())
+	if err != nil {
+		return nil, err
+	}
+	enrollCache, err := s.loadEnrollmentCache()
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]allocation.Application, len(applications))
+	for i, application := range applications {
+		apps[i] = allocation.Application{StudentID: application.StudentID, CourseID: application.CourseID}
+	}
+	result := allocation.RunLottery(enrollCache, apps, opts)
+
+	run := &models.LotteryRun{
+		TermID:         s.regState.Term(),
+		Seed:           opts.Seed,
+		WaitlistLosers: opts.WaitlistLosers,
+		Applications:   len(applications),
+	}
+	results := make([]models.LotteryResult, len(result.Draws))
+	for i, draw := range result.Draws {
+		results[i] = models.LotteryResult{
+			StudentID: draw.StudentID,
+			CourseID:  draw.CourseID,
+			DrawOrder: draw.Order,
+			Outcome:   string(draw.Outcome),
+		}
+		switch draw.Outcome {
+		case allocation.OutcomeEnrolled:
+			run.Enrolled++
+		case allocation.OutcomeWaitlisted:
+			run.Waitlisted++
+		}
+	}
+
+	if err := s.lotteryRepo.SaveRun(run, results, result.Enrollments); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// loadEnrollmentCache builds a cache from the DB for batch allocation while the worker is stopped.
+// Existing enrollments (e.g. from an earlier round) take seats and block conflicting courses.
+func (s *AdminService) loadEnrollmentCache() (*cache.EnrollmentCache, error) {
+	data, err := s.loadInitData()
+	if err != nil {
+		return nil, err
+	}
+	return cache.NewEnrollmentCache(data)
+}
+
+func (s *AdminService) GetLotteryRuns() ([]models.LotteryRun, error) {
+	return s.lotteryRepo.FetchAllRuns(s.regState.Term())
+}
+
+func (s *AdminService) GetLotteryResults(runID uint) ([]models.LotteryResult, error) {
+	return s.lotteryRepo.FetchRunResults(runID)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"log"
+
+	"course-reg/internal/app/domain/allocation"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+)
+
+// PreviewPreferenceAssignment computes the assignment without saving it.
+// The result is deterministic for a seed and the same data; its seed and checksum are needed to commit it.
+func (s *AdminService) PreviewPreferenceAssignment(seed *int64) (*allocation.PreferenceResult, error) {
+	value := s.clock.Now().UnixNano()
+	if seed != nil {
+		value = *seed
+	}
+
+	var result *allocation.PreferenceResult
+	err := s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModePreference); err != nil {
+			return err
+		}
+		var err error
+		result, err = s.assignByPreference(value)
+		return err
+	}, registration.PhaseClosed)
+	if err != nil {
+		log.Println("preview preference assignment failed:", err.Error())
+		return nil, err
+	}
+	return result, nil
+}
+
+// CommitPreferenceAssignment recomputes the assignment for seed and writes it to the enrollments table,
+// consuming all preferences. Courses and students can still change in CLOSED, so the commit is rejected
+// if the assignment no longer matches the previewed checksum.
+func (s *AdminService) CommitPreferenceAssignment(seed int64, checksum string) (*allocation.PreferenceResult, error) {
+	var result *allocation.PreferenceResult
+	err := s.editData(func() error {
+		if err := s.regState.RequireMode(registration.ModePreference); err != nil {
+			return err
+		}
+		var err error
+		result, err = s.assignByPreference(seed)
+		if err != nil {
+			return err
+		}
+		if result.Checksum != checksum {
+			return e.ErrAssignmentChanged
+		}
+		return s.preferenceRepo.CommitAssignment(s.regState.Term(), result.Enrollments)
+	}, registration.PhaseClosed)
+	if err != nil {
+		log.Println("commit preference assignment failed:", err.Error())
+		return nil, err
+	}
+
+	log.Printf("[info] preference assignment committed (seed: %d, students: %d, enrollments: %d)",
+		seed, len(result.Assignments), len(result.Enrollments))
+	return result, nil
+}
+
+func (s *AdminService) assignByPreference(seed int64) (*allocation.PreferenceResult, error) {
+	preferences, err := s.preferenceRepo.FetchAllPreferences(s.regState.Term())
+	if err != nil {
+		return nil, err
+	}
+	enrollCache, err := s.loadEnrollmentCache()
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := make([]allocation.Preference, len(preferences))
+	for i, preference := range preferences {
+		prefs[i] = allocation.Preference{
+			StudentID:  preference.StudentID,
+			CourseIDs:  preference.CourseIDs,
+			MaxCourses: preference.MaxCourses,
+		}
+	}
+	result := allocation.AssignByPreference(enrollCache, prefs, seed)
+	return &result, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/models"
+)
+
+// RecordCompletionsFromEnrollments records every current enrollment as a completed course, so the next term
+// can check prerequisites against it. Only allowed once the registration is finalized.
+func (s *AdminService) RecordCompletionsFromEnrollments() (int, error) {
+	var count int
+	err := s.editData(func() error {
+		termID := s.regState.Term()
+		courses, err := s.courseRepo.FetchAllCourses(termID)
+		if err != nil {
+			return err
+		}
+		codes := make(map[uint]string, len(courses))
+		for _, course := range courses {
+			codes[course.ID] = course.Code
+		}
+
+		enrollments, err := s.enrollRepo.FetchAllEnrollments(termID)
+		if err != nil {
+			return err
+		}
+
+		var completions []models.CourseCompletion
+		for _, enrollment := range enrollments {
+			if !enrollment.IsWaitlist {
+				completions = append(completions, models.CourseCompletion{StudentID: enrollment.StudentID, CourseCode: codes[enrollment.CourseID]})
+			}
+		}
+		count = len(completions)
+		return s.completionRepo.BatchInsertCompletions(completions)
+	}, registration.PhaseFinalized)
+	if err != nil {
+		log.Println("record completions failed:", err.Error())
+		return 0, err
+	}
+
+	log.Printf("[info] recorded %d course completions from enrollments", count)
+	return count, nil
+}
+
+// GetPrerequisites returns the prerequisites of every course code that has any (course code -> prerequisite codes)
+func (s *AdminService) GetPrerequisites() (map[string][]string, error) {
+	rows, err := s.prerequisiteRepo.FetchAllPrerequisites()
+	if err != nil {
+		return nil, err
+	}
+
+	prerequisites := make(map[string][]string)
+	for _, row := range rows {
+		prerequisites[row.CourseCode] = append(prerequisites[row.CourseCode], row.PrerequisiteCode)
+	}
+	return prerequisites, nil
+}
+
+// SetPrerequisites replaces the prerequisites of a course of the active term; an empty list removes them.
+// Prerequisites may be courses of any term and are stored by course code, so they also apply to the course
+// in later terms. Prerequisites are loaded into the worker's cache when registration opens.
+func (s *AdminService) SetPrerequisites(courseID uint, prerequisiteIDs []uint) error {
+	slices.Sort(prerequisiteIDs)
+	prerequisiteIDs = slices.Compact(prerequisiteIDs)
+
+	var course models.Course
+	var codes []string
+	err := s.editData(func() error {
+		courses, err := s.courseRepo.FetchCoursesByIDs(s.regState.Term(), []uint{courseID})
+		if err != nil {
+			return err
+		}
+		if len(courses) == 0 {
+			return fmt.Errorf("%w: %d", e.ErrCourseNotFound, courseID)
+		}
+		course = courses[0]
+
+		prerequisites, err := s.courseRepo.FetchCoursesInAnyTerm(prerequisiteIDs)
+		if err != nil {
+			return err
+		}
+		if len(prerequisites) != len(prerequisiteIDs) {
+			found := make([]uint, len(prerequisites))
+			for i, p := range prerequisites {
+				found[i] = p.ID
+			}
+			missing := slices.DeleteFunc(slices.Clone(prerequisiteIDs), func(id uint) bool { return slices.Contains(found, id) })
+			return fmt.Errorf("%w: %v", e.ErrCourseNotFound, missing)
+		}
+
+		for _, p := range prerequisites {
+			if p.Code == course.Code {
+				return fmt.Errorf("%w: a course cannot be its own prerequisite", e.ErrInvalidInput)
+			}
+			codes = append(codes, p.Code)
+		}
+		slices.Sort(codes)
+		codes = slices.Compact(codes)
+		return s.prerequisiteRepo.ReplacePrerequisites(course.Code, codes)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("set prerequisites failed:", err.Error())
+		return err
+	}
+
+	log.Printf("[info] prerequisites of course %s set to %v", course.Code, codes)
+	return nil
+}
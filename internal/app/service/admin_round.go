@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/models"
+	"course-reg/internal/pkg/utils"
+)
+
+// scheduleEditablePhases are the phases in which the registration schedule (rounds, cohorts) can be changed.
+// Changes are loaded into the registration state and apply immediately.
+var scheduleEditablePhases = []registration.Phase{
+	registration.PhaseSetup,
+	registration.PhaseOpen,
+	registration.PhasePaused,
+	registration.PhaseClosed,
+}
+
+func (s *AdminService) GetRounds() ([]models.RegistrationRound, error) {
+	return s.roundRepo.FetchAllRounds(s.regState.Term())
+}
+
+func (s *AdminService) CreateRound(round *models.RegistrationRound) (uint, error) {
+	if err := validateRound(round); err != nil {
+		log.Println("create round failed:", err.Error())
+		return 0, err
+	}
+
+	round.ID = 0
+	err := s.regState.RunInPhase(func() error {
+		round.TermID = s.regState.Term()
+		if err := s.checkRoundOverlap(round); err != nil {
+			return err
+		}
+		return s.roundRepo.InsertRound(round)
+	}, scheduleEditablePhases...)
+	if err != nil {
+		log.Println("create round failed:", err.Error())
+		return 0, err
+	}
+
+	return round.ID, s.ReloadRounds()
+}
+
+func (s *AdminService) UpdateRound(round *models.RegistrationRound) error {
+	if err := validateRound(round); err != nil {
+		log.Println("update round failed:", err.Error())
+		return err
+	}
+
+	err := s.regState.RunInPhase(func() error {
+		round.TermID = s.regState.Term()
+		if err := s.checkRoundOverlap(round); err != nil {
+			return err
+		}
+		return s.roundRepo.UpdateRound(round)
+	}, scheduleEditablePhases...)
+	if err != nil {
+		log.Println("update round failed:", err.Error())
+		return err
+	}
+
+	return s.ReloadRounds()
+}
+
+func (s *AdminService) DeleteRound(roundID uint) error {
+	err := s.regState.RunInPhase(func() error {
+		return s.roundRepo.DeleteRound(s.regState.Term(), roundID)
+	}, scheduleEditablePhases...)
+	if err != nil {
+		log.Println("delete round failed:", err.Error())
+		return err
+	}
+
+	return s.ReloadRounds()
+}
+
+func (s *AdminService) SetRoundStudents(roundID uint, studentIDs []uint) error {
+	err := s.regState.RunInPhase(func() error {
+		return s.roundRepo.ReplaceEligibleStudents(s.regState.Term(), roundID, studentIDs)
+	}, scheduleEditablePhases...)
+	if err != nil {
+		log.Println("set round students failed:", err.Error())
+		return err
+	}
+
+	return s.ReloadRounds()
+}
+
+// ReloadRounds loads rounds and their eligible students from the DB into the registration state
+func (s *AdminService) ReloadRounds() error {
+	rounds, err := s.roundRepo.FetchAllRounds(s.regState.Term())
+	if err != nil {
+		log.Println("failed to load rounds:", err.Error())
+		return err
+	}
+
+	eligible, err := s.roundRepo.FetchAllEligibleStudents(s.regState.Term())
+	if err != nil {
+		log.Println("failed to load round students:", err.Error())
+		return err
+	}
+
+	studentsByRound := make(map[uint][]uint)
+	for _, row := range eligible {
+		studentsByRound[row.RoundID] = append(studentsByRound[row.RoundID], row.StudentID)
+	}
+
+	regRounds := make([]registration.Round, 0, len(rounds))
+	for _, round := range rounds {
+		regRounds = append(regRounds, toRegRound(round, studentsByRound[round.ID]))
+	}
+
+	s.regState.SetRounds(regRounds)
+	log.Printf("[info] loaded %d registration rounds", len(regRounds))
+	return nil
+}
+
+// checkRoundOverlap rejects a round whose window overlaps another round of its term
+func (s *AdminService) checkRoundOverlap(round *models.RegistrationRound) error {
+	rounds, err := s.roundRepo.FetchAllRounds(round.TermID)
+	if err != nil {
+		return err
+	}
+	others := make([]registration.Round, len(rounds))
+	for i, other := range rounds {
+		others[i] = toRegRound(other, nil)
+	}
+	if other, ok := registration.FindOverlap(toRegRound(*round, nil), others); ok {
+		return fmt.Errorf("%w: round overlaps round %d %q", e.ErrInvalidInput, other.ID, other.Name)
+	}
+	return nil
+}
+
+// toRegRound converts a validated round to the registration state's form
+func toRegRound(round models.RegistrationRound, eligibleStudentIDs []uint) registration.Round {
+	// Rounds are validated before they are stored
+	start, _ := utils.StringToTime(round.StartTime)
+	end, _ := utils.StringToTime(round.EndTime)
+	return registration.NewRound(
+		round.ID,
+		round.Name,
+		start,
+		end,
+		registration.RoundOps(round.AllowedOps),
+		round.OpenToAll,
+		eligibleStudentIDs,
+	)
+}
+
+func validateRound(round *models.RegistrationRound) error {
+	start, err := utils.StringToTime(round.StartTime)
+	if err != nil {
+		return fmt.Errorf("%w: invalid start time: %v", e.ErrInvalidInput, err)
+	}
+	end, err := utils.StringToTime(round.EndTime)
+	if err != nil {
+		return fmt.Errorf("%w: invalid end time: %v", e.ErrInvalidInput, err)
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("%w: start time must be before end time", e.ErrInvalidInput)
+	}
+	if !registration.RoundOps(round.AllowedOps).IsValid() {
+		return fmt.Errorf("%w: unknown allowed ops %q", e.ErrInvalidInput, round.AllowedOps)
+	}
+	return nil
+}
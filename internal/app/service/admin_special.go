@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/models"
+)
+
+// GetSpecialCourseRules returns the special course limit and the eligibility rule of each special course
+func (s *AdminService) GetSpecialCourseRules() (int, []models.SpecialCourseRule, error) {
+	config, err := s.regConfigRepo.GetConfig(s.regState.Term())
+	if err != nil {
+		return 0, nil, err
+	}
+	rules, err := s.specialRuleRepo.FetchAllRules(s.regState.Term())
+	if err != nil {
+		return 0, nil, err
+	}
+	return config.MaxSpecialCourses, rules, nil
+}
+
+// SetSpecialCourseLimit sets how many special courses a student may take (0 means no limit)
+func (s *AdminService) SetSpecialCourseLimit(maxSpecialCourses int) error {
+	if maxSpecialCourses < 0 {
+		return fmt.Errorf("%w: limit cannot be negative", e.ErrInvalidInput)
+	}
+
+	err := s.editData(func() error {
+		return s.regConfigRepo.UpdateSpecialLimit(s.regState.Term(), maxSpecialCourses)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("set special course limit failed:", err.Error())
+		return err
+	}
+
+	log.Printf("[info] special course limit set to %d", maxSpecialCourses)
+	return nil
+}
+
+// SetSpecialCourseRule replaces who may enroll in a special course
+func (s *AdminService) SetSpecialCourseRule(rule *models.SpecialCourseRule) error {
+	if rule.StudentIDs == nil {
+		rule.StudentIDs = []uint{}
+	}
+	if rule.Cohorts == nil {
+		rule.Cohorts = []string{}
+	}
+
+	err := s.editData(func() error {
+		courses, err := s.courseRepo.FetchCoursesByIDs(s.regState.Term(), []uint{rule.CourseID})
+		if err != nil {
+			return err
+		}
+		if len(courses) == 0 {
+			return fmt.Errorf("%w: %d", e.ErrCourseNotFound, rule.CourseID)
+		}
+		if !courses[0].IsSpecial {
+			return fmt.Errorf("%w: course %d is not a special course", e.ErrInvalidInput, rule.CourseID)
+		}
+		return s.specialRuleRepo.SaveRule(rule)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("set special course rule failed:", err.Error())
+		return err
+	}
+
+	log.Printf("[info] special course rule set (course: %d, students: %d, cohorts: %v)", rule.CourseID, len(rule.StudentIDs), rule.Cohorts)
+	return nil
+}
+
+// DeleteSpecialCourseRule opens a special course to every student again
+func (s *AdminService) DeleteSpecialCourseRule(courseID uint) error {
+	err := s.editData(func() error {
+		exists, err := s.courseRepo.CourseExists(s.regState.Term(), courseID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: %d", e.ErrCourseNotFound, courseID)
+		}
+		return s.specialRuleRepo.DeleteRule(courseID)
+	}, dataEditablePhases...)
+	if err != nil {
+		log.Println("delete special course rule failed:", err.Error())
+		return err
+	}
+	return nil
+}
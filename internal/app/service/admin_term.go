@@ -0,0 +1,187 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/export"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/domain/rollover"
+	"course-reg/internal/app/models"
+)
+
+func (s *AdminService) GetTerms() ([]models.Term, error) {
+	return s.termRepo.FetchAllTerms()
+}
+
+// GetActiveTerm returns the ID of the term the registration currently runs in
+func (s *AdminService) GetActiveTerm() uint {
+	return s.regState.Term()
+}
+
+// CreateTerm adds an empty, inactive term with a fresh registration config in SETUP.
+// Courses and students are added to it after activating it; see RolloverTerm to copy another term.
+func (s *AdminService) CreateTerm(name string) (uint, error) {
+	name, err := s.checkNewTermName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	term := &models.Term{Name: name}
+	config := &models.RegistrationConfig{
+		Phase: string(registration.PhaseSetup),
+		Mode:  string(registration.ModeFCFS),
+	}
+	if err := s.termRepo.InsertTerm(term, config, nil, nil, nil); err != nil {
+		log.Println("create term failed:", err.Error())
+		return 0, err
+	}
+
+	log.Printf("[info] term created (id: %d, name: %s)", term.ID, term.Name)
+	return term.ID, nil
+}
+
+// ActivateTerm switches the registration to another term, loading that term's stored config and rounds.
+// Only allowed while neither term has a registration in progress (SETUP or FINALIZED).
+func (s *AdminService) ActivateTerm(termID uint) error {
+	term, err := s.termRepo.FetchTerm(termID)
+	if err != nil {
+		return err
+	}
+	config, err := s.regConfigRepo.GetConfig(termID)
+	if err != nil {
+		log.Printf("load registration config of term %d failed: %v", termID, err)
+		return err
+	}
+
+	next := registration.TermConfig{
+		Phase:     registration.Phase(config.Phase),
+		Mode:      registration.Mode(config.Mode),
+		StartTime: config.StartTime,
+		EndTime:   config.EndTime,
+	}
+	if !next.Phase.IsValid() || !next.Mode.IsValid() {
+		return fmt.Errorf("term %d has an invalid registration config (phase %q, mode %q)", termID, config.Phase, config.Mode)
+	}
+
+	err = s.regState.SwitchTermAndAct(termID, next, func() error {
+		s.StopDetachedWorker()
+		return s.termRepo.SetActiveTerm(termID)
+	})
+	if err != nil {
+		log.Println("activate term failed:", err.Error())
+		return err
+	}
+
+	export.ExportCoursesToJson(s.courseRepo, termID)
+	if err := s.ReloadRounds(); err != nil {
+		return err
+	}
+	log.Printf("[info] term %s (id: %d) activated (phase: %s, mode: %s)", term.Name, term.ID, next.Phase, next.Mode)
+	return nil
+}
+
+// GetTermCourses returns the courses of any term, including past ones
+func (s *AdminService) GetTermCourses(termID uint) ([]models.Course, error) {
+	if _, err := s.termRepo.FetchTerm(termID); err != nil {
+		return nil, err
+	}
+	return s.courseRepo.FetchAllCourses(termID)
+}
+
+// GetTermEnrollments returns the enrollments and waitlist entries of any term, including past ones
+func (s *AdminService) GetTermEnrollments(termID uint) ([]models.Enrollment, error) {
+	if _, err := s.termRepo.FetchTerm(termID); err != nil {
+		return nil, err
+	}
+	return s.enrollRepo.FetchAllEnrollments(termID)
+}
+
+// PreviewTermRollover shows what RolloverTerm would create without saving anything
+func (s *AdminService) PreviewTermRollover(opts rollover.Options) (*rollover.Plan, error) {
+	if _, err := s.checkNewTermName(opts.Name); err != nil {
+		return nil, err
+	}
+	return s.planRollover(opts)
+}
+
+// RolloverTerm creates an inactive term holding copies of the source term's courses (with the given
+// adjustments), their course groups, special course and eligibility rules, and its student roster,
+// all in one transaction. A plan with problems is rejected.
+func (s *AdminService) RolloverTerm(opts rollover.Options) (uint, *rollover.Plan, error) {
+	name, err := s.checkNewTermName(opts.Name)
+	if err != nil {
+		return 0, nil, err
+	}
+	plan, err := s.planRollover(opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(plan.Problems) > 0 {
+		return 0, plan, fmt.Errorf("%w: %s", e.ErrRolloverProblems, strings.Join(plan.Problems, "; "))
+	}
+
+	term := &models.Term{Name: name}
+	config := &models.RegistrationConfig{
+		Phase: string(registration.PhaseSetup),
+		Mode:  string(registration.ModeFCFS),
+	}
+	if err := s.termRepo.InsertTerm(term, config, plan.NewCourses(), plan.StudentIDs, plan.CopyRules); err != nil {
+		log.Println("rollover term failed:", err.Error())
+		return 0, nil, err
+	}
+
+	log.Printf("[info] term %s (id: %d) rolled over from term %d (courses: %d, course groups: %d, special rules: %d, eligibility rules: %d, students: %d)",
+		term.Name, term.ID, opts.SourceTermID, len(plan.Courses), len(plan.Rules.CourseGroups),
+		len(plan.Rules.SpecialRules), len(plan.Rules.EligibilityRules), len(plan.StudentIDs))
+	return term.ID, plan, nil
+}
+
+func (s *AdminService) planRollover(opts rollover.Options) (*rollover.Plan, error) {
+	if _, err := s.termRepo.FetchTerm(opts.SourceTermID); err != nil {
+		return nil, err
+	}
+	courses, err := s.courseRepo.FetchAllCourses(opts.SourceTermID)
+	if err != nil {
+		return nil, err
+	}
+	studentIDs, err := s.termRepo.FetchTermStudentIDs(opts.SourceTermID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules models.CourseRules
+	if rules.CourseGroups, err = s.courseGroupRepo.FetchAllGroups(opts.SourceTermID); err != nil {
+		return nil, err
+	}
+	if rules.SpecialRules, err = s.specialRuleRepo.FetchAllRules(opts.SourceTermID); err != nil {
+		return nil, err
+	}
+	if rules.EligibilityRules, err = s.eligibilityRepo.FetchAllRules(opts.SourceTermID); err != nil {
+		return nil, err
+	}
+
+	plan := rollover.Build(courses, rules, studentIDs, opts.Courses, opts.ExcludeStudentIDs)
+	return &plan, nil
+}
+
+// checkNewTermName trims the name and checks that no term has it yet
+func (s *AdminService) checkNewTermName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: term name is empty", e.ErrInvalidInput)
+	}
+
+	terms, err := s.termRepo.FetchAllTerms()
+	if err != nil {
+		return "", err
+	}
+	for _, term := range terms {
+		if term.Name == name {
+			return "", fmt.Errorf("%w: %s", e.ErrDuplicateTerm, name)
+		}
+	}
+	return name, nil
+}
@@ -1,49 +1,144 @@
 package service
 
 import (
+	"context"
 	"course-reg/internal/app/domain/constants"
+	"course-reg/internal/app/domain/idempotency"
 	"course-reg/internal/app/domain/registration"
 	"course-reg/internal/app/domain/worker"
 	"course-reg/internal/app/repository"
+	"course-reg/internal/pkg/utils"
+	"fmt"
 )
 
 type CourseRegService struct {
 	courseRepo       repository.CourseRepositoryInterface
 	enrollRepo       repository.EnrollmentRepositoryInterface
+	lotteryRepo      repository.LotteryRepositoryInterface
+	preferenceRepo   repository.PreferenceRepositoryInterface
+	cartRepo         repository.CartRepositoryInterface
 	enrollmentWorker *worker.EnrollmentWorker
 	regState         *registration.State
+	idempotencyStore *idempotency.Store
+	clock            utils.TimeProvider
 }
 
 func NewCourseRegService(
 	c repository.CourseRepositoryInterface,
 	e repository.EnrollmentRepositoryInterface,
+	l repository.LotteryRepositoryInterface,
+	p repository.PreferenceRepositoryInterface,
+	ct repository.CartRepositoryInterface,
 	w *worker.EnrollmentWorker,
 	r *registration.State,
+	idem *idempotency.Store,
+	clock utils.TimeProvider,
 ) *CourseRegService {
 	return &CourseRegService{
 		courseRepo:       c,
 		enrollRepo:       e,
+		lotteryRepo:      l,
+		preferenceRepo:   p,
+		cartRepo:         ct,
 		enrollmentWorker: w,
 		regState:         r,
+		idempotencyStore: idem,
+		clock:            clock,
 	}
 }
 
-func (s *CourseRegService) Enroll(studentID, courseID uint) error {
-	return s.regState.RunIfEnabled(true, func() error {
-		return s.enrollmentWorker.Enroll(studentID, courseID)
-	})
+// Enroll, CancelEnrollment and SwapEnrollment take the client's Idempotency-Key, which may be empty.
+// A request repeating a key gets the outcome of the first one without entering the worker queue again.
+func (s *CourseRegService) Enroll(ctx context.Context, studentID, courseID uint, idempotencyKey string) error {
+	return s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModeFCFS); err != nil {
+			return err
+		}
+		if err := s.checkAccess(studentID, registration.OpEnroll); err != nil {
+			return err
+		}
+		return s.idempotencyStore.Do(ctx, studentID, idempotencyKey, fmt.Sprintf("enroll:%d", courseID), func(ctx context.Context) (func() error, error) {
+			return s.enrollmentWorker.Submit(ctx, worker.EnrollmentRequest{Type: worker.ENROLL, StudentID: studentID, CourseID: courseID})
+		})
+	}, registration.PhaseOpen)
+}
+
+func (s *CourseRegService) CancelEnrollment(ctx context.Context, studentID, courseID uint, idempotencyKey string) error {
+	return s.regState.RunInPhase(func() error {
+		if err := s.checkAccess(studentID, registration.OpCancel); err != nil {
+			return err
+		}
+		return s.idempotencyStore.Do(ctx, studentID, idempotencyKey, fmt.Sprintf("cancel:%d", courseID), func(ctx context.Context) (func() error, error) {
+			return s.enrollmentWorker.Submit(ctx, worker.EnrollmentRequest{Type: worker.CANCEL, StudentID: studentID, CourseID: courseID})
+		})
+	}, registration.PhaseOpen)
+}
+
+// SwapEnrollment moves the student from one course to another without risking the old seat.
+// It needs both enroll and cancel to be allowed in the active round.
+func (s *CourseRegService) SwapEnrollment(ctx context.Context, studentID, fromCourseID, toCourseID uint, idempotencyKey string) error {
+	return s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModeFCFS); err != nil {
+			return err
+		}
+		if err := s.checkAccess(studentID, registration.OpCancel); err != nil {
+			return err
+		}
+		if err := s.checkAccess(studentID, registration.OpEnroll); err != nil {
+			return err
+		}
+		return s.idempotencyStore.Do(ctx, studentID, idempotencyKey, fmt.Sprintf("swap:%d:%d", fromCourseID, toCourseID), func(ctx context.Context) (func() error, error) {
+			return s.enrollmentWorker.Submit(ctx, worker.EnrollmentRequest{
+				Type:       worker.SWAP,
+				StudentID:  studentID,
+				CourseID:   fromCourseID,
+				ToCourseID: toCourseID,
+			})
+		})
+	}, registration.PhaseOpen)
+}
+
+func (s *CourseRegService) JoinWaitlist(ctx context.Context, studentID, courseID uint) (int, error) {
+	var position int
+	err := s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModeFCFS); err != nil {
+			return err
+		}
+		if err := s.checkAccess(studentID, registration.OpEnroll); err != nil {
+			return err
+		}
+		var err error
+		position, err = s.enrollmentWorker.JoinWaitlist(ctx, studentID, courseID)
+		return err
+	}, registration.PhaseOpen)
+	return position, err
+}
+
+// LeaveWaitlist never takes a seat, so it is allowed in any round
+func (s *CourseRegService) LeaveWaitlist(ctx context.Context, studentID, courseID uint) error {
+	return s.regState.RunInPhase(func() error {
+		return s.enrollmentWorker.LeaveWaitlist(ctx, studentID, courseID)
+	}, registration.PhaseOpen)
 }
 
 func (s *CourseRegService) GetAllCourseStatus() (map[uint]constants.CourseStatus, error) {
 	var result map[uint]constants.CourseStatus
-	err := s.regState.RunIfEnabled(true, func() error {
+	err := s.regState.RunInPhase(func() error {
 		result = s.enrollmentWorker.GetAllCourseStatus()
 		return nil
-	})
+	}, registration.PhaseOpen)
 	return result, err
 }
 
-// func (s *CourseRegService) CancelEnrollment(studentID, courseID uint) (bool, string, map[uint]int) {
-// 	resp := s.enrollmentWorker.CancelEnrollment(studentID, courseID)
-// 	return resp.Success, resp.Message, resp.CourseStatuses
-// }
+// checkAccess checks the active round and, for enrollments, the student's cohort entry time
+// before the request reaches the worker queue
+func (s *CourseRegService) checkAccess(studentID uint, op registration.Operation) error {
+	now := s.clock.Now()
+	if err := s.regState.CheckRoundAccess(now, studentID, op); err != nil {
+		return err
+	}
+	if op == registration.OpEnroll {
+		return s.regState.CheckEntryTime(now, studentID)
+	}
+	return nil
+}
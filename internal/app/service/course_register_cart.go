@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"course-reg/internal/app/domain/cache"
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/domain/worker"
+	"course-reg/internal/app/models"
+)
+
+// maxCartSize limits how many courses a student can put in the cart
+const maxCartSize = 30
+
+// cartEditablePhases are the phases in which students can change their cart
+var cartEditablePhases = []registration.Phase{registration.PhaseSetup, registration.PhasePaused, registration.PhaseOpen}
+
+func (s *CourseRegService) GetCart(studentID uint) ([]models.CartItem, error) {
+	return s.cartRepo.FetchCart(s.regState.Term(), studentID)
+}
+
+// AddToCart adds a course to the student's cart, rejecting courses whose schedule
+// conflicts with one already in the cart so conflicts show up before opening
+func (s *CourseRegService) AddToCart(studentID, courseID uint) error {
+	return s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModeFCFS); err != nil {
+			return err
+		}
+
+		items, err := s.cartRepo.FetchCart(s.regState.Term(), studentID)
+		if err != nil {
+			return err
+		}
+		if len(items) >= maxCartSize {
+			return e.ErrCartFull
+		}
+
+		courseIDs := []uint{courseID}
+		for _, item := range items {
+			if item.CourseID == courseID {
+				return e.ErrAlreadyInCart
+			}
+			courseIDs = append(courseIDs, item.CourseID)
+		}
+
+		courses, err := s.courseRepo.FetchCoursesByIDs(s.regState.Term(), courseIDs)
+		if err != nil {
+			return err
+		}
+		if err := checkCartConflicts(courseID, courses); err != nil {
+			return err
+		}
+
+		return s.cartRepo.InsertCartItem(&models.CartItem{TermID: s.regState.Term(), StudentID: studentID, CourseID: courseID})
+	}, cartEditablePhases...)
+}
+
+func (s *CourseRegService) RemoveFromCart(studentID, courseID uint) error {
+	return s.regState.RunInPhase(func() error {
+		return s.cartRepo.DeleteCartItem(s.regState.Term(), studentID, courseID)
+	}, cartEditablePhases...)
+}
+
+// SubmitCart enrolls the student in every course in the cart with one worker request.
+// Enrolled courses are removed from the cart; failed ones stay so the student can retry.
+func (s *CourseRegService) SubmitCart(ctx context.Context, studentID uint, allOrNothing bool) ([]worker.CourseResult, error) {
+	var results []worker.CourseResult
+	var termID uint
+	err := s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModeFCFS); err != nil {
+			return err
+		}
+		if err := s.checkAccess(studentID, registration.OpEnroll); err != nil {
+			return err
+		}
+
+		termID = s.regState.Term()
+		items, err := s.cartRepo.FetchCart(termID, studentID)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return e.ErrCartEmpty
+		}
+
+		courseIDs := make([]uint, len(items))
+		for i, item := range items {
+			courseIDs[i] = item.CourseID
+		}
+		results, err = s.enrollmentWorker.EnrollCart(ctx, studentID, courseIDs, allOrNothing)
+		return err
+	}, registration.PhaseOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	var enrolled []uint
+	for _, result := range results {
+		if result.Err == nil {
+			enrolled = append(enrolled, result.CourseID)
+		}
+	}
+	if err := s.cartRepo.DeleteCartItems(termID, studentID, enrolled); err != nil {
+		// The enrollments are already saved; a stale cart item only fails with "already enrolled" next time
+		log.Println("clear submitted cart items failed:", err.Error())
+	}
+	return results, nil
+}
+
+// checkCartConflicts checks that courseID exists in courses and does not conflict with any other of them
+func checkCartConflicts(courseID uint, courses []models.Course) error {
+	var course *models.Course
+	for i := range courses {
+		if courses[i].ID == courseID {
+			course = &courses[i]
+		}
+	}
+	if course == nil {
+		return fmt.Errorf("%w: %d", e.ErrCourseNotFound, courseID)
+	}
+
+	for _, other := range courses {
+		conflict, err := cache.CoursesConflict(*course, other)
+		if err != nil {
+			return err
+		}
+		if conflict {
+			return fmt.Errorf("%w: course %d in cart", e.ErrTimeConflict, other.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"course-reg/internal/app/domain/registration"
+)
+
+// HoldSeat reserves a seat while the student completes checkout (e.g. accepting terms or paying a fee).
+// The hold expires after worker.HoldTTL unless it is confirmed.
+func (s *CourseRegService) HoldSeat(ctx context.Context, studentID, courseID uint) (time.Time, error) {
+	var expiresAt time.Time
+	err := s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModeFCFS); err != nil {
+			return err
+		}
+		if err := s.checkAccess(studentID, registration.OpEnroll); err != nil {
+			return err
+		}
+		var err error
+		expiresAt, err = s.enrollmentWorker.HoldSeat(ctx, studentID, courseID)
+		return err
+	}, registration.PhaseOpen)
+	return expiresAt, err
+}
+
+func (s *CourseRegService) ConfirmHold(ctx context.Context, studentID, courseID uint) error {
+	return s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModeFCFS); err != nil {
+			return err
+		}
+		if err := s.checkAccess(studentID, registration.OpEnroll); err != nil {
+			return err
+		}
+		return s.enrollmentWorker.ConfirmHold(ctx, studentID, courseID)
+	}, registration.PhaseOpen)
+}
+
+// ReleaseHold never takes a seat, so it is allowed in any round
+func (s *CourseRegService) ReleaseHold(ctx context.Context, studentID, courseID uint) error {
+	return s.regState.RunInPhase(func() error {
+		return s.enrollmentWorker.ReleaseHold(ctx, studentID, courseID)
+	}, registration.PhaseOpen)
+}
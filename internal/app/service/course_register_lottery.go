@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/models"
+)
+
+// Apply submits a lottery application; seats are assigned when the admin draws after closing.
+// Cohort entry times are not checked since the order of applications does not matter.
+func (s *CourseRegService) Apply(studentID, courseID uint) error {
+	return s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModeLottery); err != nil {
+			return err
+		}
+		if err := s.regState.CheckRoundAccess(s.clock.Now(), studentID, registration.OpEnroll); err != nil {
+			return err
+		}
+
+		exists, err := s.courseRepo.CourseExists(s.regState.Term(), courseID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: %d", e.ErrCourseNotFound, courseID)
+		}
+
+		applications, err := s.lotteryRepo.FetchApplicationsByStudent(s.regState.Term(), studentID)
+		if err != nil {
+			return err
+		}
+		for _, application := range applications {
+			if application.CourseID == courseID {
+				return e.ErrAlreadyApplied
+			}
+		}
+
+		return s.lotteryRepo.InsertApplication(&models.LotteryApplication{TermID: s.regState.Term(), StudentID: studentID, CourseID: courseID})
+	}, registration.PhaseOpen)
+}
+
+func (s *CourseRegService) WithdrawApplication(studentID, courseID uint) error {
+	return s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModeLottery); err != nil {
+			return err
+		}
+		return s.lotteryRepo.DeleteApplication(s.regState.Term(), studentID, courseID)
+	}, registration.PhaseOpen)
+}
+
+func (s *CourseRegService) GetApplications(studentID uint) ([]models.LotteryApplication, error) {
+	return s.lotteryRepo.FetchApplicationsByStudent(s.regState.Term(), studentID)
+}
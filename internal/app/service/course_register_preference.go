@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+
+	"course-reg/internal/app/domain/e"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/models"
+)
+
+// maxPreferenceCourses limits the length of a ranked list
+const maxPreferenceCourses = 30
+
+// SubmitPreference saves the student's ranked course list, replacing any earlier one
+func (s *CourseRegService) SubmitPreference(studentID uint, courseIDs []uint, maxCourses int) error {
+	if len(courseIDs) == 0 || len(courseIDs) > maxPreferenceCourses {
+		return fmt.Errorf("%w: between 1 and %d courses must be ranked", e.ErrInvalidInput, maxPreferenceCourses)
+	}
+	if maxCourses < 1 || maxCourses > len(courseIDs) {
+		return fmt.Errorf("%w: max courses must be between 1 and the number of ranked courses", e.ErrInvalidInput)
+	}
+	seen := make(map[uint]struct{}, len(courseIDs))
+	for _, courseID := range courseIDs {
+		if _, dup := seen[courseID]; dup {
+			return fmt.Errorf("%w: course %d is ranked twice", e.ErrInvalidInput, courseID)
+		}
+		seen[courseID] = struct{}{}
+	}
+
+	return s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModePreference); err != nil {
+			return err
+		}
+		if err := s.regState.CheckRoundAccess(s.clock.Now(), studentID, registration.OpEnroll); err != nil {
+			return err
+		}
+
+		for _, courseID := range courseIDs {
+			exists, err := s.courseRepo.CourseExists(s.regState.Term(), courseID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: %d", e.ErrCourseNotFound, courseID)
+			}
+		}
+
+		return s.preferenceRepo.SavePreference(&models.CoursePreference{
+			TermID:     s.regState.Term(),
+			StudentID:  studentID,
+			CourseIDs:  courseIDs,
+			MaxCourses: maxCourses,
+		})
+	}, registration.PhaseOpen)
+}
+
+func (s *CourseRegService) WithdrawPreference(studentID uint) error {
+	return s.regState.RunInPhase(func() error {
+		if err := s.regState.RequireMode(registration.ModePreference); err != nil {
+			return err
+		}
+		return s.preferenceRepo.DeletePreference(s.regState.Term(), studentID)
+	}, registration.PhaseOpen)
+}
+
+func (s *CourseRegService) GetPreference(studentID uint) (*models.CoursePreference, error) {
+	return s.preferenceRepo.FetchPreference(s.regState.Term(), studentID)
+}
@@ -1,9 +1,16 @@
 package service
 
 import (
+	"context"
+	"course-reg/internal/app/domain/allocation"
+	"course-reg/internal/app/domain/cache"
 	"course-reg/internal/app/domain/constants"
+	"course-reg/internal/app/domain/registration"
+	"course-reg/internal/app/domain/rollover"
+	"course-reg/internal/app/domain/worker"
 	"course-reg/internal/app/models"
 	"course-reg/internal/pkg/session"
+	"time"
 )
 
 type AdminServiceInterface interface {
@@ -14,13 +21,72 @@ type AdminServiceInterface interface {
 	CreateCourse(*models.Course) (uint, error)
 	DeleteCourse(uint) error
 
-	GetRegistrationState() bool
+	GetRegistrationState() registration.Phase
 	StartRegistration() error
 	PauseRegistration() error
-	// GetRegistrationPeriod() (string, string)
-	// SetRegistrationPeriod(string, string) error
+	CloseRegistration() error
+	FinalizeRegistration() error
+	PrepareRegistration() error
+	GetRegistrationPeriod() (string, string)
+	SetRegistrationPeriod(string, string) error
 
 	ResetEnrollments() error
+	ForceEnroll(ctx context.Context, studentID, courseID uint, overrides worker.Overrides, reason string) error
+	ForceCancel(ctx context.Context, studentID, courseID uint, reason string) error
+	GetAdminEnrollmentLogs() ([]models.AdminEnrollmentLog, error)
+
+	GetLimits() (cache.Limit, []models.StudentLimit, error)
+	SetDefaultLimit(cache.Limit) error
+	SetStudentLimit(studentID uint, limit cache.Limit) error
+	DeleteStudentLimit(studentID uint) error
+
+	GetCourseGroups() ([]models.CourseGroup, error)
+	CreateCourseGroup(*models.CourseGroup) (uint, error)
+	DeleteCourseGroup(groupID uint) error
+
+	GetEligibilityRules() ([]models.EligibilityRule, error)
+	CreateEligibilityRule(*models.EligibilityRule) (uint, error)
+	DeleteEligibilityRule(ruleID uint) error
+	GetStudentGroups() (map[string][]uint, error)
+	SetStudentGroup(groupName string, studentIDs []uint) error
+	ImportCompletions([]models.CourseCompletion) error
+	RecordCompletionsFromEnrollments() (int, error)
+	GetPrerequisites() (map[string][]string, error)
+	SetPrerequisites(courseID uint, prerequisiteIDs []uint) error
+
+	GetTerms() ([]models.Term, error)
+	GetActiveTerm() uint
+	CreateTerm(name string) (uint, error)
+	ActivateTerm(termID uint) error
+	PreviewTermRollover(rollover.Options) (*rollover.Plan, error)
+	RolloverTerm(rollover.Options) (uint, *rollover.Plan, error)
+	GetTermCourses(termID uint) ([]models.Course, error)
+	GetTermEnrollments(termID uint) ([]models.Enrollment, error)
+
+	GetSpecialCourseRules() (int, []models.SpecialCourseRule, error)
+	SetSpecialCourseLimit(maxSpecialCourses int) error
+	SetSpecialCourseRule(*models.SpecialCourseRule) error
+	DeleteSpecialCourseRule(courseID uint) error
+
+	GetRounds() ([]models.RegistrationRound, error)
+	CreateRound(*models.RegistrationRound) (uint, error)
+	UpdateRound(*models.RegistrationRound) error
+	DeleteRound(uint) error
+	SetRoundStudents(roundID uint, studentIDs []uint) error
+
+	GetCohorts() ([]models.Cohort, error)
+	SetCohorts([]models.Cohort) error
+	AssignCohorts(assignments map[uint]string) error
+	AssignRandomCohorts(cohortNames []string, seed int64) (map[uint]string, error)
+
+	GetRegistrationMode() registration.Mode
+	SetRegistrationMode(registration.Mode) error
+	DrawLottery(seed *int64, waitlistLosers bool) (*models.LotteryRun, error)
+	GetLotteryRuns() ([]models.LotteryRun, error)
+	GetLotteryResults(runID uint) ([]models.LotteryResult, error)
+
+	PreviewPreferenceAssignment(seed *int64) (*allocation.PreferenceResult, error)
+	CommitPreferenceAssignment(seed int64, checksum string) (*allocation.PreferenceResult, error)
 }
 
 type AuthServiceInterface interface {
@@ -28,7 +94,26 @@ type AuthServiceInterface interface {
 }
 
 type CourseRegServiceInterface interface {
-	Enroll(studentID, courseID uint) error
+	Enroll(ctx context.Context, studentID, courseID uint, idempotencyKey string) error
 	GetAllCourseStatus() (map[uint]constants.CourseStatus, error)
-	// CancelEnrollment(studentID, courseID uint) (success bool, message string, allSeats map[uint]int)
+	CancelEnrollment(ctx context.Context, studentID, courseID uint, idempotencyKey string) error
+	SwapEnrollment(ctx context.Context, studentID, fromCourseID, toCourseID uint, idempotencyKey string) error
+	JoinWaitlist(ctx context.Context, studentID, courseID uint) (int, error)
+	LeaveWaitlist(ctx context.Context, studentID, courseID uint) error
+	HoldSeat(ctx context.Context, studentID, courseID uint) (time.Time, error)
+	ConfirmHold(ctx context.Context, studentID, courseID uint) error
+	ReleaseHold(ctx context.Context, studentID, courseID uint) error
+
+	Apply(studentID, courseID uint) error
+	WithdrawApplication(studentID, courseID uint) error
+	GetApplications(studentID uint) ([]models.LotteryApplication, error)
+
+	SubmitPreference(studentID uint, courseIDs []uint, maxCourses int) error
+	WithdrawPreference(studentID uint) error
+	GetPreference(studentID uint) (*models.CoursePreference, error)
+
+	GetCart(studentID uint) ([]models.CartItem, error)
+	AddToCart(studentID, courseID uint) error
+	RemoveFromCart(studentID, courseID uint) error
+	SubmitCart(ctx context.Context, studentID uint, allOrNothing bool) ([]worker.CourseResult, error)
 }
@@ -23,9 +23,48 @@ func Setup(url string, poolSize int, connMaxLifetime, connMaxIdleTime time.Durat
 		return nil, fmt.Errorf("[fatal] failed to connect database: %w", err)
 	}
 
-	if err := db.AutoMigrate(&models.Student{}, &models.Course{}, &models.Enrollment{}, &models.RegistrationConfig{}); err != nil {
+	if err := db.AutoMigrate(
+		&models.Term{},
+		&models.TermStudent{},
+		&models.Student{},
+		&models.Course{},
+		&models.Enrollment{},
+		&models.RegistrationConfig{},
+		&models.RegistrationRound{},
+		&models.RoundEligibleStudent{},
+		&models.Cohort{},
+		&models.LotteryApplication{},
+		&models.LotteryRun{},
+		&models.LotteryResult{},
+		&models.CoursePreference{},
+		&models.CartItem{},
+		&models.AdminEnrollmentLog{},
+		&models.StudentLimit{},
+		&models.SpecialCourseRule{},
+		&models.CourseGroup{},
+		&models.EligibilityRule{},
+		&models.StudentGroupMember{},
+		&models.CourseCompletion{},
+		&models.CoursePrerequisite{},
+	); err != nil {
 		return nil, fmt.Errorf("[fatal] failed to migrate database: %w", err)
 	}
+	// Courses created before course codes existed are identified by their name
+	if err := db.Model(&models.Course{}).Where("code = ?", "").Update("code", gorm.Expr("name")).Error; err != nil {
+		return nil, fmt.Errorf("[fatal] failed to migrate course codes: %w", err)
+	}
+	// Configs from before phases kept only whether registration was enabled; an enabled one was open
+	if db.Migrator().HasColumn(&models.RegistrationConfig{}, "enabled") {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("UPDATE registration_configs SET phase = ? WHERE enabled", "OPEN").Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&models.RegistrationConfig{}, "enabled")
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[fatal] failed to migrate registration phase: %w", err)
+		}
+	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
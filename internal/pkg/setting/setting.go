@@ -14,6 +14,7 @@ type Config struct {
 	Server   Server
 	Secret   Secret
 	Database Database
+	Worker   Worker
 }
 
 type App struct {
@@ -36,6 +37,13 @@ type Secret struct {
 	AdminPW    string
 }
 
+type Worker struct {
+	QueueSize      int           // enrollment requests waiting beyond this are rejected as busy
+	Shards         int           // enrollment worker goroutines; 1 decides every request on a single goroutine
+	RequestTimeout time.Duration // how long a request may wait for the worker, queue included
+	JournalPath    string        // local file enrollments are written to before the DB; empty disables the journal
+}
+
 type Database struct {
 	URL             string
 	PoolSize        int
@@ -66,6 +74,12 @@ func Load() *Config {
 			ConnMaxLifetime: time.Duration(getEnvAsIntRequired("DATABASE_CONN_MAX_LIFETIME")) * time.Minute,
 			ConnMaxIdleTime: time.Duration(getEnvAsIntRequired("DATABASE_CONN_MAX_IDLE_TIME")) * time.Minute,
 		},
+		Worker: Worker{
+			QueueSize:      getEnvAsIntRequired("WORKER_QUEUE_SIZE"),
+			Shards:         getEnvAsIntRequired("WORKER_SHARDS"),
+			RequestTimeout: time.Duration(getEnvAsIntRequired("WORKER_REQUEST_TIMEOUT")) * time.Second,
+			JournalPath:    os.Getenv("WORKER_JOURNAL_PATH"),
+		},
 		Secret: Secret{
 			SessionKey: getEnvRequired("SECRET_SESSION_KEY"),
 			AdminID:    getEnvRequired("SECRET_ADMIN_ID"),
@@ -19,6 +19,10 @@ func (p *KoreaTimeProvider) Now() time.Time {
 	return time.Now().In(loc)
 }
 
+// koreaZone is a fixed KST offset (Korea has no DST), so parsing does not depend on tzdata in the image
+var koreaZone = time.FixedZone("KST", 9*60*60)
+
+// StringToTime parses "2006-01-02-15-04" formatted time as Korea time
 func StringToTime(timeStr string) (time.Time, error) {
-	return time.Parse("2006-01-02-15-04", timeStr)
+	return time.ParseInLocation("2006-01-02-15-04", timeStr, koreaZone)
 }